@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// truthyValues and falsyValues are the strings, besides those accepted by strconv.ParseBool,
+// which boolean settings accept, compared case-insensitively.
+var (
+	truthyValues = map[string]bool{"yes": true, "y": true, "on": true}
+	falsyValues  = map[string]bool{"no": true, "n": true, "off": true}
+)
+
+// parseBool parses the string form of a boolean setting. Besides the values accepted by
+// strconv.ParseBool, "yes", "y" and "on" are true and "no", "n" and "off" are false.
+func parseBool(s string) (bool, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case truthyValues[value]:
+		return true, nil
+	case falsyValues[value]:
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean %q", s)
+	}
+	return enabled, nil
+}
+
+// settingDecodeHook converts the string forms of settings, as given in the environment or on the
+// command line, into their types: durations, comma-separated lists and booleans as parseBool does.
+func settingDecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		boolDecodeHook,
+	)
+}
+
+// boolDecodeHook decodes strings into boolean settings with parseBool.
+func boolDecodeHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+		return data, nil
+	}
+	return parseBool(data.(string))
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestParseBool(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"yes", "on", "1", "true", "Y", "ON", " True "} {
+		enabled, err := parseBool(value)
+		require.NoError(t, err, value)
+		assert.True(t, enabled, value)
+	}
+	for _, value := range []string{"no", "off", "0", "false", "N", "Off"} {
+		enabled, err := parseBool(value)
+		require.NoError(t, err, value)
+		assert.False(t, enabled, value)
+	}
+
+	_, err := parseBool("maybe")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid boolean "maybe"`)
+}
+
+func TestTolerantBoolSettings(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+
+	t.Run("EnvOverride", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte("allow_private_registry_ip: false\n"), 0600))
+		t.Setenv("TOOLHIVE_ALLOW_PRIVATE_REGISTRY_IP", "yes")
+		t.Setenv("TOOLHIVE_SECRETS_SETUP_COMPLETED", "off")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.True(t, config.AllowPrivateRegistryIp)
+		assert.False(t, config.Secrets.SetupCompleted)
+	})
+
+	t.Run("InvalidEnvOverride", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		t.Setenv("TOOLHIVE_ALLOW_PRIVATE_REGISTRY_IP", "maybe")
+
+		_, err := LoadOrCreateConfigWithPath(configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid boolean "maybe"`)
+	})
+
+	t.Run("SetValidated", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+
+		require.NoError(t, config.SetValidated("features.beta", "on"))
+		assert.True(t, config.Features["beta"])
+		assert.Error(t, config.SetValidated("features.beta", "sometimes"))
+	})
+
+	t.Run("FeatureEnvVar", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		RegisterFeature("tolerant-bool-test", false)
+		t.Setenv(featureEnvVar("tolerant-bool-test"), "yes")
+		assert.True(t, (&Config{}).FeatureEnabled("tolerant-bool-test"))
+	})
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strings"
+	"sync"
+)
+
+// settingCache memoizes the settings read through CachedString and CachedBool, by dotted key.
+// A config gets an empty cache whenever its settings are resolved, and whenever it is cloned to
+// stage changes, so that changes applied to it through ConfigTxn, SetValidated or ApplyOverrides
+// replace its cache along with its settings.
+type settingCache struct {
+	values sync.Map
+}
+
+// CachedString returns the setting at the given dotted key, or the entry of a map setting, in the
+// string form returned by GetRaw, or "" if there is no such setting. Values are looked up once and
+// then served from memory, which suits settings read on every request. The cache is safe for
+// concurrent use, and a config reloaded by a Watcher starts with an empty cache, so reads through
+// Watcher.Config always see the reloaded values.
+func (c *Config) CachedString(path string) string {
+	value := c.cachedSetting(path)
+	if value == nil {
+		return ""
+	}
+	raw, _ := formatSetting(value)
+	return raw
+}
+
+// CachedBool returns the boolean setting at the given dotted key, or the entry of a boolean map
+// setting such as "features.beta", as CachedString does. It returns false if there is no such
+// setting or it is not a boolean.
+func (c *Config) CachedBool(path string) bool {
+	value, _ := c.cachedSetting(path).(bool)
+	return value
+}
+
+// cachedSetting returns the setting at the given dotted path, looking it up only the first time it is read.
+// Configs which were not loaded, and so have no cache, look it up every time.
+func (c *Config) cachedSetting(path string) any {
+	path = strings.ToLower(path)
+	if c.cache == nil {
+		return settingAt(c, path)
+	}
+	if value, ok := c.cache.values.Load(path); ok {
+		return value
+	}
+	value := settingAt(c, path)
+	c.cache.values.Store(path, value)
+	return value
+}
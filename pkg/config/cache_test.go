@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestCachedSettings(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	w, _ := newTestWatcher(t, `registry_url: https://example.com/registry.json
+allow_private_registry_ip: true
+otel:
+  endpoint: collector:4318
+features:
+  beta: true
+`)
+	config := w.Config()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "https://example.com/registry.json", config.CachedString("registry_url"))
+			assert.Equal(t, "collector:4318", config.CachedString("OTEL.Endpoint"))
+			assert.True(t, config.CachedBool("allow_private_registry_ip"))
+			assert.True(t, config.CachedBool("features.beta"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, config.CachedString("unknown"))
+	assert.False(t, config.CachedBool("features.unknown"))
+	assert.False(t, config.CachedBool("registry_url"))
+}
+
+func TestCachedSettingsInvalidatedOnReload(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	w, configPath := newTestWatcher(t, "registry_url: https://old.example.com/registry.json\n")
+	assert.Equal(t, "https://old.example.com/registry.json", w.Config().CachedString("registry_url"))
+
+	require.NoError(t, os.WriteFile(configPath, []byte("registry_url: https://new.example.com/registry.json\n"), 0600))
+	_, err := w.Reload(ReloadSourceManual)
+	require.NoError(t, err)
+	assert.Equal(t, "https://new.example.com/registry.json", w.Config().CachedString("registry_url"))
+}
+
+func TestCachedSettingsInvalidatedByChanges(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	w, _ := newTestWatcher(t, "otel:\n  endpoint: file-collector:4318\n")
+	config := w.Config()
+	assert.Equal(t, "file-collector:4318", config.CachedString("otel.endpoint"))
+
+	require.NoError(t, config.ApplyOverrides([]string{"otel.endpoint=flag-collector:4318"}))
+	assert.Equal(t, "flag-collector:4318", config.CachedString("otel.endpoint"))
+
+	txn := config.Begin()
+	require.NoError(t, txn.Set("otel.endpoint", "txn-collector:4318"))
+	require.NoError(t, txn.Commit())
+	assert.Equal(t, "txn-collector:4318", config.CachedString("otel.endpoint"))
+}
+
+func BenchmarkCachedString(b *testing.B) {
+	configPath := filepath.Join(b.TempDir(), "config.yaml")
+	require.NoError(b, os.WriteFile(configPath, []byte("otel:\n  endpoint: collector:4318\n"), 0600))
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	require.NoError(b, err)
+
+	b.Run("Cached", func(b *testing.B) {
+		for b.Loop() {
+			_ = config.CachedString("otel.endpoint")
+		}
+	})
+	b.Run("Uncached", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = config.GetRaw("otel.endpoint")
+		}
+	})
+}
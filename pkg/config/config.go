@@ -3,15 +3,18 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/gofrs/flock"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 
 	"github.com/stacklok/toolhive/pkg/logger"
@@ -23,14 +26,36 @@ const lockTimeout = 1 * time.Second
 
 // Config represents the configuration of the application.
 type Config struct {
+	SchemaVersion          int                 `yaml:"schema_version,omitempty"`
 	Secrets                Secrets             `yaml:"secrets"`
 	Clients                Clients             `yaml:"clients"`
-	RegistryUrl            string              `yaml:"registry_url"`
-	LocalRegistryPath      string              `yaml:"local_registry_path"`
+	RegistryUrl            string              `yaml:"registry_url" sensitive:"userinfo"`
+	LocalRegistryPath      string              `yaml:"local_registry_path" path:"home"`
 	AllowPrivateRegistryIp bool                `yaml:"allow_private_registry_ip"`
-	CACertificatePath      string              `yaml:"ca_certificate_path,omitempty"`
+	CACertificatePath      string              `yaml:"ca_certificate_path,omitempty" path:"home"`
 	OTEL                   OpenTelemetryConfig `yaml:"otel,omitempty"`
 	DefaultGroupMigration  bool                `yaml:"default_group_migration,omitempty"`
+	Features               map[string]bool     `yaml:"features,omitempty"`
+	Servers                []ServerConfig      `yaml:"servers,omitempty" unique:"name"`
+	Timeouts               map[string]string   `yaml:"timeouts,omitempty" element:"duration"`
+	Validations            []ValidationRule    `yaml:"validations,omitempty"`
+
+	// values holds the settings explicitly provided in the config file.
+	values *viper.Viper
+	// env holds the settings overridden in the environment.
+	env *viper.Viper
+	// dir is the directory the config was loaded from, against which relative paths are resolved.
+	dir string
+	// file is the path of the config file the config was loaded from, to which SetValidated persists changes.
+	file string
+	// secrets records the settings whose secret references have been resolved.
+	secrets []resolvedSecret
+	// flags holds the dotted keys of the settings overridden by ApplyOverrides.
+	flags []string
+	// cache holds the settings read through CachedString and CachedBool.
+	cache *settingCache
+	// seal holds the options the config was sealed with by Seal, or nil if it is not sealed.
+	seal *SealOptions
 }
 
 // Secrets contains the settings for secrets management.
@@ -89,6 +114,7 @@ var getConfigPath = defaultPathGenerator
 // createNewConfigWithDefaults creates a new config with default values
 func createNewConfigWithDefaults() Config {
 	return Config{
+		SchemaVersion: CurrentSchemaVersion,
 		Secrets: Secrets{
 			ProviderType:   "", // No default provider - user must run setup
 			SetupCompleted: false,
@@ -101,35 +127,57 @@ func createNewConfigWithDefaults() Config {
 
 // applyBackwardCompatibility applies backward compatibility fixes to existing configs
 func applyBackwardCompatibility(config *Config) error {
-	// Hack - if the secrets provider type is set to the old `basic` type,
-	// just change it to `encrypted`.
-	if config.Secrets.ProviderType == "basic" {
-		fmt.Println("cleaning up basic secrets provider")
-		// Attempt to cleanup path, treat errors as non fatal.
-		oldPath, err := xdg.DataFile("toolhive/secrets")
-		if err == nil {
-			_ = os.Remove(oldPath)
+	for _, fix := range compatibilityFixes {
+		if !fix.apply(config) {
+			continue
 		}
-		config.Secrets.ProviderType = string(secrets.EncryptedType)
-		err = config.save()
-		if err != nil {
-			return fmt.Errorf("error updating config: %v", err)
+		if fix.cleanup != nil {
+			fix.cleanup()
 		}
-	}
-
-	// Handle backward compatibility: if provider is set but setup_completed is false,
-	// consider it as setup completed (for existing users)
-	if config.Secrets.ProviderType != "" && !config.Secrets.SetupCompleted {
-		config.Secrets.SetupCompleted = true
 		err := config.save()
 		if err != nil {
 			return fmt.Errorf("error updating config for backward compatibility: %v", err)
 		}
 	}
-
 	return nil
 }
 
+// compatibilityFixes are the fixes applied by applyBackwardCompatibility, in order.
+var compatibilityFixes = []migration{
+	{
+		description: "replace the removed basic secrets provider with the encrypted provider",
+		// Hack - if the secrets provider type is set to the old `basic` type,
+		// just change it to `encrypted`.
+		apply: func(config *Config) bool {
+			if config.Secrets.ProviderType != "basic" {
+				return false
+			}
+			config.Secrets.ProviderType = string(secrets.EncryptedType)
+			return true
+		},
+		cleanup: func() {
+			fmt.Println("cleaning up basic secrets provider")
+			// Attempt to cleanup path, treat errors as non fatal.
+			oldPath, err := xdg.DataFile("toolhive/secrets")
+			if err == nil {
+				_ = os.Remove(oldPath)
+			}
+		},
+	},
+	{
+		description: "mark the configured secrets provider as set up",
+		// Handle backward compatibility: if provider is set but setup_completed is false,
+		// consider it as setup completed (for existing users)
+		apply: func(config *Config) bool {
+			if config.Secrets.ProviderType == "" || config.Secrets.SetupCompleted {
+				return false
+			}
+			config.Secrets.SetupCompleted = true
+			return true
+		},
+	},
+}
+
 // LoadOrCreateConfig fetches the application configuration.
 // If it does not already exist - it will create a new config file with default values.
 func LoadOrCreateConfig() (*Config, error) {
@@ -139,7 +187,87 @@ func LoadOrCreateConfig() (*Config, error) {
 // LoadOrCreateConfigWithPath fetches the application configuration from a specific path.
 // If configPath is empty, it uses the default path.
 // If it does not already exist - it will create a new config file with default values.
+// Settings provided through ConfigJSONEnvVar are merged over those of the file, and settings
+// overridden in the environment (see EnvPrefix) are applied on top of both.
 func LoadOrCreateConfigWithPath(configPath string) (*Config, error) {
+	config, err := loadOrCreateConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.applyConfigJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// resolve checks the keys of a loaded config, normalizes its paths, applies the environment
+// overrides on top of it, resolves its secret and file references and validates the result.
+func (c *Config) resolve() error {
+	return c.resolveWith((*Config).Validate)
+}
+
+// resolveWith resolves a loaded config as resolve does, validating the result with validate
+// instead of Validate. Validation is skipped if validate is nil.
+func (c *Config) resolveWith(validate Validator) error {
+	c.cache = &settingCache{}
+	err := c.checkUnknownKeys()
+	if err != nil {
+		return err
+	}
+
+	err = c.checkValidationRules()
+	if err != nil {
+		return err
+	}
+
+	err = c.normalizePaths()
+	if err != nil {
+		return fmt.Errorf("invalid config paths: %w", err)
+	}
+
+	err = c.applyEnvOverrides()
+	if err != nil {
+		return fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
+	err = c.resolveSecrets(context.Background())
+	if err != nil {
+		return err
+	}
+
+	err = c.resolveFileReferences()
+	if err != nil {
+		return err
+	}
+
+	err = c.applyDerivations()
+	if err != nil {
+		return err
+	}
+
+	if validate == nil {
+		return nil
+	}
+	err = validate(c)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	return nil
+}
+
+// loadOrCreateConfigFile fetches the application configuration as stored on disk,
+// creating it with default values if it does not already exist.
+// If configPath is empty, it uses the default path.
+func loadOrCreateConfigFile(configPath string) (*Config, error) {
 	var config Config
 	var err error
 
@@ -154,7 +282,7 @@ func LoadOrCreateConfigWithPath(configPath string) (*Config, error) {
 	configPath = path.Clean(configPath)
 	newConfig := false
 	// #nosec G304: File path is not configurable at this time.
-	_, err = os.Stat(configPath)
+	info, err := os.Stat(configPath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			newConfig = true
@@ -180,21 +308,58 @@ func LoadOrCreateConfigWithPath(configPath string) (*Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("unable to read config file %s: %w", configPath, err)
 		}
-		err = yaml.Unmarshal(configFile, &config)
+		err = config.decode(configFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse config file yaml: %w", err)
+			return nil, err
 		}
 
-		// Apply backward compatibility fixes
-		err = applyBackwardCompatibility(&config)
+		err = config.checkFilePermissions(configPath, info)
+		if err != nil {
+			return nil, err
+		}
+
+		err = config.checkSchemaVersion(configPath)
+		if err != nil {
+			return nil, err
+		}
+		err = config.migrateSchema()
 		if err != nil {
-			return nil, fmt.Errorf("failed to apply backward compatibility fixes: %w", err)
+			return nil, err
 		}
 	}
 
+	config.dir = path.Dir(configPath)
+	config.file = configPath
 	return &config, nil
 }
 
+// Render serializes the config struct and returns the exact bytes that would be
+// written to disk when saving it, without touching the filesystem.
+// Resolved secrets are rendered as the references they were resolved from, and settings
+// read from files are rendered as the files they were read from.
+func (c *Config) Render() ([]byte, error) {
+	var doc yaml.Node
+	err := doc.Encode(c.withSecretReferences())
+	if err != nil {
+		return nil, fmt.Errorf("error serializing config file: %w", err)
+	}
+	c.renderFileKeys(&doc)
+
+	configBytes, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing config file: %w", err)
+	}
+	return configBytes, nil
+}
+
+// IsSet reports whether the setting at the given dotted path, e.g. "secrets.setup_completed",
+// was explicitly provided in the config file, the environment or by ApplyOverrides. This distinguishes an
+// explicit zero value such as false, 0 or "" from a setting which is absent and left at its default.
+func (c *Config) IsSet(path string) bool {
+	return (c.values != nil && c.values.IsSet(path)) || (c.env != nil && c.env.IsSet(path)) ||
+		slices.Contains(c.flags, path)
+}
+
 // Save serializes the config struct and writes it to disk.
 func (c *Config) save() error {
 	return c.saveToPath("")
@@ -211,9 +376,9 @@ func (c *Config) saveToPath(configPath string) error {
 		}
 	}
 
-	configBytes, err := yaml.Marshal(c)
+	configBytes, err := c.Render()
 	if err != nil {
-		return fmt.Errorf("error serializing config file: %w", err)
+		return err
 	}
 
 	err = os.WriteFile(configPath, configBytes, 0600)
@@ -257,8 +422,9 @@ func UpdateConfigAtPath(configPath string, updateFn func(*Config)) error {
 	}
 	defer fileLock.Unlock()
 
-	// Load the config after acquiring the lock to avoid race conditions
-	c, err := LoadOrCreateConfigWithPath(configPath)
+	// Load the config after acquiring the lock to avoid race conditions.
+	// Environment overrides are not applied, so that they are never persisted.
+	c, err := loadOrCreateConfigFile(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load config from disk: %w", err)
 	}
@@ -276,9 +442,32 @@ func UpdateConfigAtPath(configPath string, updateFn func(*Config)) error {
 	return nil
 }
 
+// decode parses the contents of a config file into c, keeping its raw settings for IsSet.
+func (c *Config) decode(configFile []byte) error {
+	err := yaml.Unmarshal(configFile, c)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file yaml: %w", err)
+	}
+	c.values, err = readValues(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file yaml: %w", err)
+	}
+	return nil
+}
+
+// readValues reads the raw settings of a config file so they can be queried by their dotted path.
+func readValues(configFile []byte) (*viper.Viper, error) {
+	values := viper.New()
+	values.SetConfigType("yaml")
+	if err := values.ReadConfig(bytes.NewReader(configFile)); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // OpenTelemetryConfig contains the settings for OpenTelemetry configuration.
 type OpenTelemetryConfig struct {
-	Endpoint     string   `yaml:"endpoint,omitempty"`
+	Endpoint     string   `yaml:"endpoint,omitempty" sensitive:"userinfo"`
 	SamplingRate float64  `yaml:"sampling-rate,omitempty"`
 	EnvVars      []string `yaml:"env-vars,omitempty"`
 }
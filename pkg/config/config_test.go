@@ -39,6 +39,28 @@ func SetupTestConfig(t *testing.T, configContent *Config) (string, string) {
 	return tempDir, configPath
 }
 
+// SetupTestDefaultConfigPath points the default config path at a config file holding content in a
+// temporary directory for the duration of the test, and returns its path. Tests calling it must not
+// run in parallel.
+func SetupTestDefaultConfigPath(t *testing.T, content string) string {
+	t.Helper()
+	_, configPath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	previous := getConfigPath
+	getConfigPath = func() (string, error) { return configPath, nil }
+	t.Cleanup(func() { getConfigPath = previous })
+	return configPath
+}
+
+// loadTestConfig writes content to a temporary config file and loads it with LoadOrCreateConfigWithPath.
+func loadTestConfig(t *testing.T, content string) (*Config, error) {
+	t.Helper()
+	_, configPath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	return LoadOrCreateConfigWithPath(configPath)
+}
+
 func TestLoadOrCreateConfig(t *testing.T) {
 	t.Parallel()
 	logger.Initialize()
@@ -311,3 +333,74 @@ func TestSecrets_GetProviderType_EnvironmentVariable(t *testing.T) {
 	assert.Error(t, err, "Should return error when setup not completed")
 	assert.ErrorIs(t, err, secrets.ErrSecretsNotSetup, "Should return ErrSecretsNotSetup when setup not completed")
 }
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	tempDir, configPath := SetupTestConfig(t, nil)
+	t.Cleanup(func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Logf("Failed to remove temp dir: %v", err)
+		}
+	})
+
+	config := &Config{
+		Secrets: Secrets{
+			ProviderType:   string(secrets.EncryptedType),
+			SetupCompleted: true,
+		},
+		Clients: Clients{
+			RegisteredClients: []string{"vscode", "cursor"},
+		},
+		RegistryUrl: "https://example.com/registry.json",
+		OTEL: OpenTelemetryConfig{
+			Endpoint:     "localhost:4318",
+			SamplingRate: 0.5,
+		},
+	}
+
+	rendered, err := config.Render()
+	require.NoError(t, err)
+
+	// Rendering must not create the config file
+	_, err = os.Stat(configPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = config.saveToPath(configPath)
+	require.NoError(t, err)
+
+	saved, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, saved, rendered)
+}
+
+func TestIsSet(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	tests := []struct {
+		name     string
+		content  string
+		path     string
+		expected bool
+	}{
+		{"ExplicitFalse", "default_group_migration: false\n", "default_group_migration", true},
+		{"Omitted", "registry_url: \"\"\n", "default_group_migration", false},
+		{"ExplicitEmptyString", "registry_url: \"\"\n", "registry_url", true},
+		{"ExplicitZeroNested", "otel:\n  sampling-rate: 0\n", "otel.sampling-rate", true},
+		{"OmittedNested", "otel:\n  endpoint: localhost:4318\n", "otel.sampling-rate", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, configPath := SetupTestConfig(t, nil)
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.content), 0600))
+
+			config, err := LoadOrCreateConfigWithPath(configPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.IsSet(tt.path))
+		})
+	}
+}
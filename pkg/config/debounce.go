@@ -0,0 +1,130 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+const (
+	// ReloadDebounceEnvVar is the environment variable holding the window, as a duration such as
+	// "500ms", within which changes to the config file coalesce into a single reload.
+	ReloadDebounceEnvVar = "CONFIG_RELOAD_DEBOUNCE"
+
+	// DefaultReloadDebounce is the default window within which changes coalesce into a single reload.
+	DefaultReloadDebounce = 200 * time.Millisecond
+)
+
+// WithReloadDebounce sets the window within which changes notified through NotifyChange coalesce
+// into a single reload, overriding ReloadDebounceEnvVar. A zero window reloads on every change.
+func WithReloadDebounce(window time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.debounce = window
+	}
+}
+
+// NotifyChange reports that the config file has changed. The config is reloaded, with
+// ReloadSourceFile as its source, once no further change has been notified for the debounce
+// window, so that the several events editors produce for a single save cause a single reload.
+// The reload is skipped if the content of the file is unchanged. Reload errors are logged,
+// and the current config is kept.
+func (w *Watcher) NotifyChange() {
+	if w.debounce <= 0 {
+		w.reloadFromFile()
+		return
+	}
+
+	w.debounceMu.Lock()
+	defer w.debounceMu.Unlock()
+	if w.debounceTimer == nil {
+		w.debounceTimer = time.AfterFunc(w.debounce, w.reloadFromFile)
+		return
+	}
+	w.debounceTimer.Reset(w.debounce)
+}
+
+// Watch watches the config file for changes until ctx is done, notifying them through NotifyChange.
+// The directory containing the file is watched, so that files replaced by editors are followed.
+func (w *Watcher) Watch(ctx context.Context) error {
+	fileWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch config file: %w", err)
+	}
+	defer fileWatcher.Close()
+
+	configPath := filepath.Clean(w.path)
+	if err := fileWatcher.Add(filepath.Dir(configPath)); err != nil {
+		return fmt.Errorf("failed to watch config file %s: %w", configPath, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.stopDebounce()
+			return nil
+		case event, ok := <-fileWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == configPath && changesContent(event) {
+				w.NotifyChange()
+			}
+		case err, ok := <-fileWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warnf("error watching config file %s: %v", configPath, err)
+		}
+	}
+}
+
+// changesContent reports whether event may have changed the content of the file, which is not the
+// case of events only reporting a change of its metadata. Such events may be combined with others.
+func changesContent(event fsnotify.Event) bool {
+	return event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename)
+}
+
+// reloadFromFile reloads the config after a change to the config file, unless the content of the
+// file is unchanged since it was last loaded, as happens when only its metadata changed.
+func (w *Watcher) reloadFromFile() {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	checksum, err := fileChecksum(w.path)
+	w.mu.RLock()
+	unchanged := err == nil && checksum == w.checksum
+	w.mu.RUnlock()
+	if unchanged {
+		logger.Debugf("config file %s is unchanged, skipping reload", w.path)
+		return
+	}
+
+	if _, err := w.reloadLocked(ReloadSourceFile); err != nil {
+		logger.Warnf("keeping current config: %v", err)
+	}
+}
+
+// stopDebounce cancels a pending debounced reload.
+func (w *Watcher) stopDebounce() {
+	w.debounceMu.Lock()
+	defer w.debounceMu.Unlock()
+	if w.debounceTimer != nil {
+		w.debounceTimer.Stop()
+	}
+}
+
+// reloadDebounce returns the debounce window set through ReloadDebounceEnvVar,
+// or DefaultReloadDebounce if it is unset or invalid.
+func reloadDebounce() time.Duration {
+	window, err := time.ParseDuration(os.Getenv(ReloadDebounceEnvVar))
+	if err != nil || window < 0 {
+		return DefaultReloadDebounce
+	}
+	return window
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// registryChanges records the values the registry URL is changed to by reloads.
+type registryChanges struct {
+	mu     sync.Mutex
+	values []any
+}
+
+func (r *registryChanges) record(_, newValue any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, newValue)
+}
+
+func (r *registryChanges) get() []any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]any{}, r.values...)
+}
+
+func registryConfig(n int) []byte {
+	return []byte(fmt.Sprintf("registry_url: https://registry%d.example.com/registry.json\n", n))
+}
+
+func TestWatcherDebounce(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	const window = 100 * time.Millisecond
+
+	t.Run("RapidChangesCoalesce", func(t *testing.T) {
+		t.Parallel()
+		w, configPath := newTestWatcher(t, string(registryConfig(0)), WithReloadDebounce(window))
+		changes := &registryChanges{}
+		w.WatchKey("registry_url", changes.record)
+
+		for i := 1; i <= 5; i++ {
+			require.NoError(t, os.WriteFile(configPath, registryConfig(i), 0600))
+			w.NotifyChange()
+		}
+
+		require.Eventually(t, func() bool { return len(changes.get()) > 0 }, 5*time.Second, 10*time.Millisecond)
+		time.Sleep(2 * window)
+		assert.Equal(t, []any{"https://registry5.example.com/registry.json"}, changes.get())
+		assert.Len(t, w.ConfigHistory(), 1)
+		assert.Equal(t, ReloadSourceFile, w.ConfigHistory()[0].Source)
+	})
+
+	t.Run("LaterChangeReloadsAgain", func(t *testing.T) {
+		t.Parallel()
+		w, configPath := newTestWatcher(t, string(registryConfig(0)), WithReloadDebounce(window))
+		changes := &registryChanges{}
+		w.WatchKey("registry_url", changes.record)
+
+		require.NoError(t, os.WriteFile(configPath, registryConfig(1), 0600))
+		w.NotifyChange()
+		require.Eventually(t, func() bool { return len(changes.get()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, os.WriteFile(configPath, registryConfig(2), 0600))
+		w.NotifyChange()
+		require.Eventually(t, func() bool { return len(changes.get()) == 2 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []any{
+			"https://registry1.example.com/registry.json",
+			"https://registry2.example.com/registry.json",
+		}, changes.get())
+	})
+
+	t.Run("WatchFile", func(t *testing.T) {
+		t.Parallel()
+		w, configPath := newTestWatcher(t, string(registryConfig(0)), WithReloadDebounce(window))
+		changes := &registryChanges{}
+		w.WatchKey("registry_url", changes.record)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watching := make(chan error, 1)
+		go func() { watching <- w.Watch(ctx) }()
+
+		// The watch is set up asynchronously, so keep writing until the change is noticed.
+		require.Eventually(t, func() bool {
+			_ = os.WriteFile(configPath, registryConfig(1), 0600)
+			return len(changes.get()) > 0
+		}, 5*time.Second, 2*window)
+		assert.Equal(t, "https://registry1.example.com/registry.json", w.Config().RegistryUrl)
+
+		cancel()
+		require.NoError(t, <-watching)
+	})
+}
+
+func TestWatcherSkipsUnchangedContent(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+	w, configPath := newTestWatcher(t, string(registryConfig(1)), WithReloadDebounce(0))
+	changes := &registryChanges{}
+	w.WatchKey("allow_private_registry_ip", changes.record)
+
+	// A change to the environment is only picked up by reloads, so it reveals whether one happened.
+	t.Setenv("TOOLHIVE_ALLOW_PRIVATE_REGISTRY_IP", "true")
+
+	// Touching the file and changing its permissions leaves its content unchanged.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(configPath, later, later))
+	require.NoError(t, os.Chmod(configPath, 0640))
+	w.NotifyChange()
+	assert.Empty(t, changes.get())
+	assert.False(t, w.Config().AllowPrivateRegistryIp)
+
+	require.NoError(t, os.WriteFile(configPath, registryConfig(2), 0600))
+	w.NotifyChange()
+	assert.Equal(t, []any{true}, changes.get())
+	assert.Equal(t, "https://registry2.example.com/registry.json", w.Config().RegistryUrl)
+
+	// Manual reloads are never skipped.
+	t.Setenv("TOOLHIVE_ALLOW_PRIVATE_REGISTRY_IP", "false")
+	_, err := w.Reload(ReloadSourceManual)
+	require.NoError(t, err)
+	assert.Equal(t, []any{true, false}, changes.get())
+}
+
+func TestChangesContent(t *testing.T) {
+	t.Parallel()
+	for op, changes := range map[fsnotify.Op]bool{
+		fsnotify.Write:                   true,
+		fsnotify.Create:                  true,
+		fsnotify.Rename:                  true,
+		fsnotify.Write | fsnotify.Chmod:  true,
+		fsnotify.Create | fsnotify.Chmod: true,
+		fsnotify.Chmod:                   false,
+		fsnotify.Remove:                  false,
+	} {
+		assert.Equal(t, changes, changesContent(fsnotify.Event{Name: "config.yaml", Op: op}), op.String())
+	}
+}
+
+func TestReloadDebounce(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(ReloadDebounceEnvVar, "")
+	assert.Equal(t, DefaultReloadDebounce, reloadDebounce())
+
+	t.Setenv(ReloadDebounceEnvVar, "1s")
+	assert.Equal(t, time.Second, reloadDebounce())
+
+	t.Setenv(ReloadDebounceEnvVar, "soon")
+	assert.Equal(t, DefaultReloadDebounce, reloadDebounce())
+}
@@ -0,0 +1,23 @@
+package config
+
+// DefaultConfig returns a config holding the default value of every setting, as written to a new config file.
+func DefaultConfig() *Config {
+	config := createNewConfigWithDefaults()
+	return &config
+}
+
+// NonDefaults returns the settings of the effective config whose values differ from those of
+// DefaultConfig, by dotted key, for reviewing what has been changed. Empty and absent lists or maps
+// are considered equal. The values of sensitive settings and of resolved secrets are redacted.
+func (c *Config) NonDefaults() map[string]any {
+	changed := Diff(DefaultConfig(), c)
+	settings := make(map[string]any, len(changed))
+	for _, key := range changed {
+		value := settingAt(c, key)
+		if isSensitiveValue(key, value) || c.holdsResolvedSecret(key) {
+			value = redactedValue
+		}
+		settings[key] = value
+	}
+	return settings
+}
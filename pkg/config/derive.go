@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Derivation computes the value of a setting from other settings of a config, for settings whose
+// default depends on them. It reports false if no value can be derived, leaving the setting unset.
+// Derivations must be deterministic and must not modify the config.
+type Derivation func(*Config) (any, bool)
+
+// derivation is a Derivation registered for a setting.
+type derivation struct {
+	key    string
+	derive Derivation
+}
+
+var (
+	derivations     []derivation
+	derivationsLock = &sync.RWMutex{}
+)
+
+// RegisterDerivation registers a derivation of the setting at the given dotted key, e.g. "otel.endpoint",
+// or of an entry of a map setting, e.g. "features.beta". When a config is loaded, after the config file
+// and environment overrides have been applied and before it is validated, derivations populate the
+// settings which are not set explicitly in either. Derivations run in registration order, so a
+// derivation sees the settings populated by those registered before it.
+func RegisterDerivation(key string, derive Derivation) {
+	derivationsLock.Lock()
+	defer derivationsLock.Unlock()
+	derivations = append(derivations, derivation{key: strings.ToLower(key), derive: derive})
+}
+
+// applyDerivations populates the settings which are not set explicitly with the registered derivations.
+func (c *Config) applyDerivations() error {
+	derivationsLock.RLock()
+	defer derivationsLock.RUnlock()
+	for _, d := range derivations {
+		if c.IsSet(d.key) {
+			continue
+		}
+		if _, ok := settingType(d.key); !ok {
+			return fmt.Errorf("failed to derive %s: unknown config key", d.key)
+		}
+		value, ok := d.derive(c)
+		if !ok {
+			continue
+		}
+		if err := setSetting(c, d.key, value); err != nil {
+			return fmt.Errorf("failed to derive %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestRegisterDerivation(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+
+	// Derivations are global, so they only act on configs using the test registry.
+	const testRegistry = "https://derivations.example.com/registry.json"
+	RegisterDerivation("otel.endpoint", func(c *Config) (any, bool) {
+		if c.RegistryUrl != testRegistry {
+			return nil, false
+		}
+		registry, err := url.Parse(c.RegistryUrl)
+		if err != nil {
+			return nil, false
+		}
+		return registry.Hostname() + ":4318", true
+	})
+	RegisterDerivation("otel.sampling-rate", func(c *Config) (any, bool) {
+		if c.RegistryUrl != testRegistry || c.OTEL.Endpoint == "" {
+			return nil, false
+		}
+		return 0.1, true
+	})
+
+	t.Run("DerivedWhenUnset", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		config, err := loadTestConfig(t, "registry_url: "+testRegistry+"\n")
+		require.NoError(t, err)
+		assert.Equal(t, "derivations.example.com:4318", config.OTEL.Endpoint)
+		assert.Equal(t, 0.1, config.OTEL.SamplingRate, "derivations see the settings derived before them")
+	})
+
+	t.Run("FileValueKept", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		config, err := loadTestConfig(t, "registry_url: "+testRegistry+"\notel:\n  endpoint: collector:4318\n  sampling-rate: 0\n")
+		require.NoError(t, err)
+		assert.Equal(t, "collector:4318", config.OTEL.Endpoint)
+		assert.Zero(t, config.OTEL.SamplingRate, "explicit zero values are not derived")
+	})
+
+	t.Run("EnvValueKept", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv("TOOLHIVE_OTEL_ENDPOINT", "env-collector:4318")
+		config, err := loadTestConfig(t, "registry_url: "+testRegistry+"\n")
+		require.NoError(t, err)
+		assert.Equal(t, "env-collector:4318", config.OTEL.Endpoint)
+	})
+
+	t.Run("OtherConfigsUntouched", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		config, err := loadTestConfig(t, "registry_url: https://example.com/registry.json\n")
+		require.NoError(t, err)
+		assert.Empty(t, config.OTEL.Endpoint)
+	})
+}
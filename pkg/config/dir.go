@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFileExtensions are the extensions of the files read by LoadFromDir.
+var configFileExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+}
+
+// LoadFromDir loads the application configuration from a directory of fragment files,
+// such as a mounted Kubernetes ConfigMap. Every YAML file in the directory is read in
+// name order and merged into the previous ones, so that settings from later files override
+// earlier ones; nested sections are merged key by key, while lists are replaced as a whole.
+// Hidden files and files without a .yaml or .yml extension are skipped. Each file is checked for
+// its schema version and permissions as a config file is, and the merged settings are migrated to
+// CurrentSchemaVersion. Environment overrides are applied on top of the merged settings, and the
+// result is validated. Nothing is written to disk.
+func LoadFromDir(dir string) (*Config, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config directory %s: %w", dir, err)
+	}
+
+	merged := map[string]any{}
+	found := false
+	// os.ReadDir returns the entries sorted by filename.
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || !configFileExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+
+		filePath := filepath.Join(dir, name)
+		// Stat follows symlinks, which is how ConfigMap keys are mounted.
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to stat config file %s: %w", filePath, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		// #nosec G304: The file is one of the fragments in the directory being loaded.
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read config file %s: %w", filePath, err)
+		}
+		var fragment map[string]any
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return nil, fmt.Errorf("failed to parse config file yaml %s: %w", filePath, err)
+		}
+		if err := checkFragment(filePath, info, data); err != nil {
+			return nil, err
+		}
+		mergeValues(merged, fragment)
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("no config files found in %s", dir)
+	}
+
+	configFile, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge config files: %w", err)
+	}
+
+	config := createNewConfigWithDefaults()
+	err = config.decode(configFile)
+	if err != nil {
+		return nil, err
+	}
+	config.migrateSchemaInMemory()
+	config.dir = dir
+
+	err = config.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// checkFragment runs the checks made on a config file when loading it on the fragment at filePath.
+func checkFragment(filePath string, info os.FileInfo, data []byte) error {
+	var fragment Config
+	if err := fragment.decode(data); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", filePath, err)
+	}
+	if err := fragment.checkFilePermissions(filePath, info); err != nil {
+		return err
+	}
+	return fragment.checkSchemaVersion(filePath)
+}
+
+// mergeValues merges src into dst, recursing into maps present in both.
+func mergeValues(dst, src map[string]any) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
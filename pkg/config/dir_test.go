@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func writeFragments(t *testing.T, fragments map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range fragments {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestLoadFromDir(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	t.Run("MergesInNameOrder", func(t *testing.T) {
+		t.Parallel()
+		dir := writeFragments(t, map[string]string{
+			"10-registry.yaml": `registry_url: https://first.example.com/registry.json
+otel:
+  endpoint: otel.example.com:4318
+  env-vars: [USER]
+`,
+			"20-otel.yml": `otel:
+  sampling-rate: 0.5
+  env-vars: [HOME, PATH]
+`,
+			"30-override.yaml": `registry_url: https://last.example.com/registry.json
+allow_private_registry_ip: true
+`,
+			"README.md":    "registry_url: https://ignored.example.com\n",
+			".hidden.yaml": "registry_url: https://hidden.example.com\n",
+		})
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.yaml"), 0700))
+
+		config, err := LoadFromDir(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "https://last.example.com/registry.json", config.RegistryUrl)
+		assert.True(t, config.AllowPrivateRegistryIp)
+		assert.Equal(t, OpenTelemetryConfig{
+			Endpoint:     "otel.example.com:4318",
+			SamplingRate: 0.5,
+			EnvVars:      []string{"HOME", "PATH"},
+		}, config.OTEL)
+		assert.True(t, config.IsSet("otel.endpoint"))
+		assert.False(t, config.IsSet("ca_certificate_path"))
+	})
+
+	t.Run("InvalidFragment", func(t *testing.T) {
+		t.Parallel()
+		dir := writeFragments(t, map[string]string{
+			"config.yaml": "registry_url: [unterminated\n",
+		})
+
+		_, err := LoadFromDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config.yaml")
+	})
+
+	t.Run("SchemaVersion", func(t *testing.T) {
+		t.Parallel()
+		dir := writeFragments(t, map[string]string{
+			"10-registry.yaml": "registry_url: https://registry.example.com/registry.json\n",
+		})
+
+		config, err := LoadFromDir(dir)
+		require.NoError(t, err)
+		assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion, "unversioned fragments are migrated")
+
+		dir = writeFragments(t, map[string]string{
+			"10-registry.yaml": "registry_url: https://registry.example.com/registry.json\n",
+			"20-newer.yaml":    "schema_version: 99\n",
+		})
+		_, err = LoadFromDir(dir)
+		require.ErrorIs(t, err, ErrConfigTooNew)
+		assert.Contains(t, err.Error(), "20-newer.yaml has schema version 99")
+	})
+
+	t.Run("InvalidResult", func(t *testing.T) {
+		t.Parallel()
+		dir := writeFragments(t, map[string]string{
+			"servers.yaml": "servers:\n  - name: fetch\n",
+		})
+
+		_, err := LoadFromDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "servers[0].image")
+	})
+
+	t.Run("NoConfigFiles", func(t *testing.T) {
+		t.Parallel()
+		dir := writeFragments(t, map[string]string{
+			"notes.txt": "nothing to see here\n",
+		})
+
+		_, err := LoadFromDir(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no config files found")
+	})
+
+	t.Run("MissingDirectory", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromDir(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+func TestLoadFromDirDoesNotSave(t *testing.T) { //nolint:paralleltest // Replaces the default config path
+	logger.Initialize()
+	const content = "registry_url: https://default.example.com/registry.json\n"
+	defaultPath := SetupTestDefaultConfigPath(t, content)
+
+	dir := writeFragments(t, map[string]string{
+		"10-secrets.yaml": "secrets:\n  provider_type: basic\n",
+	})
+	config, err := LoadFromDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "encrypted", config.Secrets.ProviderType, "older settings are migrated")
+
+	data, err := os.ReadFile(defaultPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data), "the default config file is left as it is")
+	data, err = os.ReadFile(filepath.Join(dir, "10-secrets.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "secrets:\n  provider_type: basic\n", string(data), "the fragments are left as they are")
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadDotEnv sets the environment variables defined in the dotenv file at path, so that they
+// override config file settings as environment overrides do (see EnvPrefix). Variables already set
+// in the environment take precedence over the file and are left unchanged. It must be called before
+// the config is loaded. A missing file is ignored.
+//
+// Each line holds a KEY=VALUE pair, optionally preceded by "export". Blank lines and lines starting
+// with "#" are ignored. Values may be enclosed in single quotes, taken literally, or in double quotes,
+// in which \n, \t, \" and \\ are unescaped. A "#" preceded by whitespace starts a comment in unquoted values.
+func LoadDotEnv(path string) error {
+	// #nosec G304: The dotenv file is provided by the caller.
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read dotenv file %s: %w", path, err)
+	}
+
+	vars, err := parseDotEnv(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse dotenv file %s: %w", path, err)
+	}
+	for _, v := range vars {
+		if _, ok := os.LookupEnv(v.name); ok {
+			continue
+		}
+		if err := os.Setenv(v.name, v.value); err != nil {
+			return fmt.Errorf("unable to set %s from dotenv file %s: %w", v.name, path, err)
+		}
+	}
+	return nil
+}
+
+// dotEnvVar is a variable defined in a dotenv file.
+type dotEnvVar struct {
+	name  string
+	value string
+}
+
+// parseDotEnv returns the variables defined in the content of a dotenv file, in order.
+func parseDotEnv(content string) ([]dotEnvVar, error) {
+	var vars []dotEnvVar
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		name, raw, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		value, err := parseDotEnvValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", lineNumber, name, err)
+		}
+		vars = append(vars, dotEnvVar{name: name, value: value})
+	}
+	return vars, scanner.Err()
+}
+
+// parseDotEnvValue returns the value of a variable from its form in a dotenv file.
+func parseDotEnvValue(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, "'"):
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated single-quoted value")
+		}
+		return raw[1 : end+1], nil
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return "", errors.New("unterminated double-quoted value")
+		}
+		value, err := strconv.Unquote(raw[:end+1])
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted value: %w", err)
+		}
+		return value, nil
+	default:
+		if i := strings.Index(raw, " #"); i >= 0 {
+			raw = raw[:i]
+		}
+		if i := strings.Index(raw, "\t#"); i >= 0 {
+			raw = raw[:i]
+		}
+		return strings.TrimSpace(raw), nil
+	}
+}
+
+// closingQuote returns the index of the double quote closing the value starting at raw[0], or -1.
+func closingQuote(raw string) int {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
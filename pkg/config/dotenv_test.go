@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// unsetEnv unsets an environment variable for the duration of the test.
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv(name, "")
+	require.NoError(t, os.Unsetenv(name))
+}
+
+func TestLoadDotEnv(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+	unsetEnv(t, "TOOLHIVE_OTEL_ENDPOINT")
+	unsetEnv(t, "TOOLHIVE_OTEL_SAMPLING_RATE")
+	t.Setenv("TOOLHIVE_REGISTRY_URL", "https://env.example.com/registry.json")
+
+	dotEnvPath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(dotEnvPath, []byte(`# Local overrides
+TOOLHIVE_OTEL_ENDPOINT="dotenv-collector:4318" # quoted
+export TOOLHIVE_OTEL_SAMPLING_RATE=0.5
+TOOLHIVE_REGISTRY_URL=https://dotenv.example.com/registry.json
+`), 0600))
+	require.NoError(t, LoadDotEnv(dotEnvPath))
+
+	_, configPath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(configPath, []byte(`otel:
+  endpoint: file-collector:4318
+  sampling-rate: 0.1
+`), 0600))
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dotenv-collector:4318", config.OTEL.Endpoint, "dotenv values override the config file")
+	assert.Equal(t, 0.5, config.OTEL.SamplingRate)
+	assert.Equal(t, SourceEnv, config.SourceOf("otel.endpoint"))
+	assert.Equal(t, "https://env.example.com/registry.json", config.RegistryUrl,
+		"variables set in the environment take precedence over the dotenv file")
+}
+
+func TestLoadDotEnvMissingFile(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, LoadDotEnv(filepath.Join(t.TempDir(), ".env")))
+}
+
+func TestParseDotEnv(t *testing.T) {
+	t.Parallel()
+
+	vars, err := parseDotEnv(`
+# comment
+PLAIN=value # trailing comment
+EMPTY=
+SINGLE='literal $HOME \n # kept'
+DOUBLE="line\nbreak \"quoted\"" # comment
+HASH=pass#word
+  export SPACED = spaced value
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []dotEnvVar{
+		{name: "PLAIN", value: "value"},
+		{name: "EMPTY", value: ""},
+		{name: "SINGLE", value: `literal $HOME \n # kept`},
+		{name: "DOUBLE", value: "line\nbreak \"quoted\""},
+		{name: "HASH", value: "pass#word"},
+		{name: "SPACED", value: "spaced value"},
+	}, vars)
+
+	for content, message := range map[string]string{
+		"NOVALUE":          "line 1: expected KEY=VALUE",
+		"=value":           "line 1: expected KEY=VALUE",
+		"A=1\nB='unclosed": `line 2: B: unterminated single-quoted value`,
+		`C="unclosed`:      `line 1: C: unterminated double-quoted value`,
+	} {
+		_, err := parseDotEnv(content)
+		require.Error(t, err, content)
+		assert.Contains(t, err.Error(), message)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// elementTag names the constraint each element of a list setting, or each value of a map setting,
+// must satisfy, e.g. element:"url".
+const elementTag = "element"
+
+// ElementConstraint checks an element of a list setting, or a value of a map setting, returning the
+// reason it is invalid, or nil.
+type ElementConstraint func(element string) error
+
+var (
+	elementConstraints = map[string]ElementConstraint{
+		"url":      validURL,
+		"env_var":  validEnvVarName,
+		"duration": validDuration,
+	}
+	elementConstraintsLock = &sync.RWMutex{}
+)
+
+// RegisterElementConstraint registers a constraint which list and map settings can require of their
+// elements by naming it in their element tag. Validate reports each element violating it along with
+// its index, or its key for map settings.
+// Registering the same name twice overwrites the previous constraint.
+func RegisterElementConstraint(name string, constraint ElementConstraint) {
+	elementConstraintsLock.Lock()
+	defer elementConstraintsLock.Unlock()
+	elementConstraints[name] = constraint
+}
+
+// GetStringSlice returns a copy of the list setting at the given dotted key, or nil if there is no
+// such setting or it is not a list of strings.
+func (c *Config) GetStringSlice(path string) []string {
+	elements, _ := settingAt(c, strings.ToLower(path)).([]string)
+	return slices.Clone(elements)
+}
+
+// GetDurationMap returns the map setting at the given dotted key, such as timeouts, with each value
+// parsed as a duration, or nil if there is no such setting or it is not a map of strings. Entries
+// which are not valid durations, which Validate reports, are left out. Keys missing from the map are
+// read as 0, which callers should take to mean that their own default applies.
+func (c *Config) GetDurationMap(path string) map[string]time.Duration {
+	values, ok := settingAt(c, strings.ToLower(path)).(map[string]string)
+	if !ok {
+		return nil
+	}
+	durations := make(map[string]time.Duration, len(values))
+	for key, value := range values {
+		if d, err := time.ParseDuration(value); err == nil {
+			durations[key] = d
+		}
+	}
+	return durations
+}
+
+// validateElements returns the elements of list and map settings which violate the constraint named by
+// their element tag, each prefixed with the key of the setting and the index or key of the element.
+func (c *Config) validateElements() []error {
+	return elementErrors(reflect.ValueOf(c).Elem())
+}
+
+// elementErrors checks the elements of the list and map settings of the struct value against the
+// constraints named by their element tags.
+func elementErrors(value reflect.Value) []error {
+	elementConstraintsLock.RLock()
+	defer elementConstraintsLock.RUnlock()
+
+	var errs []error
+	for _, field := range collectSchemaFields(value.Type(), "", nil) {
+		name := field.Field.Tag.Get(elementTag)
+		if name == "" {
+			continue
+		}
+		constraint, ok := elementConstraints[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown element constraint %q", field.Key, name))
+			continue
+		}
+		switch elements := value.FieldByIndex(field.Index).Interface().(type) {
+		case []string:
+			for i, element := range elements {
+				if err := constraint(element); err != nil {
+					errs = append(errs, fmt.Errorf("%s[%d]: %w", field.Key, i, err))
+				}
+			}
+		case map[string]string:
+			for _, key := range slices.Sorted(maps.Keys(elements)) {
+				if err := constraint(elements[key]); err != nil {
+					errs = append(errs, fmt.Errorf("%s.%s: %w", field.Key, key, err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func validURL(element string) error {
+	u, err := url.Parse(element)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: must be absolute", element)
+	}
+	return nil
+}
+
+func validDuration(element string) error {
+	if _, err := time.ParseDuration(element); err != nil {
+		return fmt.Errorf("invalid duration %q", element)
+	}
+	return nil
+}
+
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validEnvVarName checks the name of an environment variable, which may be followed by a value as NAME=value.
+func validEnvVarName(element string) error {
+	name, _, _ := strings.Cut(element, "=")
+	if !envVarNamePattern.MatchString(name) {
+		return errors.New("invalid environment variable name: " + element)
+	}
+	return nil
+}
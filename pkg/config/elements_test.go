@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStringSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ValidElements", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `otel:
+  env-vars: [USER, HOME, _DEBUG, MODE=dev]
+`)
+		require.NoError(t, err)
+
+		elements := config.GetStringSlice("otel.env-vars")
+		assert.Equal(t, []string{"USER", "HOME", "_DEBUG", "MODE=dev"}, elements)
+		elements[0] = "PATH"
+		assert.Equal(t, "USER", config.OTEL.EnvVars[0], "a copy of the setting is returned")
+		assert.Nil(t, config.GetStringSlice("otel.endpoint"))
+		assert.Nil(t, config.GetStringSlice("otel.unknown"))
+	})
+
+	t.Run("NotConstrained", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `otel:
+  env-vars: [USER, 1-HOME=/home]
+`)
+		require.NoError(t, err, "existing list settings have no element constraint")
+		assert.Equal(t, []string{"USER", "1-HOME=/home"}, config.GetStringSlice("otel.env-vars"))
+	})
+}
+
+// elementSettings is a schema holding list and map settings with element constraints.
+type elementSettings struct {
+	EnvVars  []string          `yaml:"env-vars" element:"env_var"`
+	Mirrors  []string          `yaml:"mirrors" element:"url"`
+	Timeouts map[string]string `yaml:"timeouts" element:"duration"`
+}
+
+func TestElementConstraints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings elementSettings
+		errMsgs  []string
+	}{
+		{
+			name: "Valid",
+			settings: elementSettings{
+				EnvVars:  []string{"USER", "HOME", "_DEBUG", "MODE=dev"},
+				Mirrors:  []string{"https://mirror.example.com/registry.json"},
+				Timeouts: map[string]string{"read": "5s"},
+			},
+		},
+		{
+			name:     "InvalidEnvVar",
+			settings: elementSettings{EnvVars: []string{"USER", "1-HOME=/home", "PATH"}},
+			errMsgs:  []string{"env-vars[1]: invalid environment variable name: 1-HOME=/home"},
+		},
+		{
+			name:     "InvalidURL",
+			settings: elementSettings{Mirrors: []string{"https://mirror.example.com", "mirror.example.com"}},
+			errMsgs:  []string{`mirrors[1]: invalid URL "mirror.example.com": must be absolute`},
+		},
+		{
+			name:     "InvalidDuration",
+			settings: elementSettings{Timeouts: map[string]string{"read": "5s", "write": "soon"}},
+			errMsgs:  []string{`timeouts.write: invalid duration "soon"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var messages []string
+			for _, err := range elementErrors(reflect.ValueOf(tt.settings)) {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, tt.errMsgs, messages)
+		})
+	}
+}
+
+func TestGetDurationMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ValidDurations", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `timeouts:
+  read: 5s
+  write: 10s
+  pull: 1m30s
+`)
+		require.NoError(t, err)
+
+		timeouts := config.GetDurationMap("timeouts")
+		assert.Equal(t, map[string]time.Duration{
+			"read":  5 * time.Second,
+			"write": 10 * time.Second,
+			"pull":  90 * time.Second,
+		}, timeouts)
+		assert.Zero(t, timeouts["connect"], "missing keys read as 0")
+		assert.Nil(t, config.GetDurationMap("registry_url"))
+	})
+
+	t.Run("InvalidDuration", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTestConfig(t, `timeouts:
+  read: 5s
+  write: ten seconds
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `timeouts.write: invalid duration "ten seconds"`)
+		assert.NotContains(t, err.Error(), "timeouts.read")
+	})
+}
+
+func TestRegisterElementConstraint(t *testing.T) {
+	t.Parallel()
+
+	RegisterElementConstraint("no_secret", func(element string) error {
+		if element == "SECRET" {
+			return errors.New("must not be exported")
+		}
+		return nil
+	})
+
+	type labelSettings struct {
+		Labels []string `yaml:"labels" element:"no_secret"`
+	}
+	errs := elementErrors(reflect.ValueOf(labelSettings{Labels: []string{"USER", "SECRET"}}))
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "labels[1]: must not be exported")
+}
+
+func TestValidURL(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, validURL("https://example.com/path"))
+	assert.Error(t, validURL("example.com"))
+	assert.Error(t, validURL("://bad"))
+}
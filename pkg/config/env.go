@@ -0,0 +1,102 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the default prefix of the environment variables which override config file
+// settings. It can be changed with SetEnvPrefix.
+//
+// The variable name of a setting is derived from its dotted key by upper-casing it and
+// replacing both "." and "-" with "_", so "otel.endpoint" is overridden by
+// TOOLHIVE_OTEL_ENDPOINT and "otel.sampling-rate" by TOOLHIVE_OTEL_SAMPLING_RATE.
+// Because names are derived from the keys of the schema, rather than parsed back into
+// keys, underscores within a key are unambiguous: TOOLHIVE_SECRETS_PROVIDER_TYPE always
+// overrides "secrets.provider_type". Lists of values are given as comma-separated strings,
+// and booleans as any of true/false, yes/no, on/off or 1/0.
+// Lists of sections and maps, such as servers and features, cannot be overridden.
+const EnvPrefix = "TOOLHIVE"
+
+var (
+	envPrefix     = EnvPrefix
+	envPrefixLock = &sync.RWMutex{}
+)
+
+// SetEnvPrefix changes the prefix of the environment variables which override config file
+// settings for the configs loaded afterwards, so that several ToolHive instances with distinct
+// prefixes can share an environment. With the prefix "STAGING", "otel.endpoint" is overridden by
+// STAGING_OTEL_ENDPOINT and no longer by TOOLHIVE_OTEL_ENDPOINT. A trailing underscore is optional,
+// and an empty prefix restores EnvPrefix. Feature flags keep using FeatureEnvPrefix.
+func SetEnvPrefix(prefix string) {
+	prefix = strings.TrimSuffix(prefix, "_")
+	if prefix == "" {
+		prefix = EnvPrefix
+	}
+	envPrefixLock.Lock()
+	defer envPrefixLock.Unlock()
+	envPrefix = prefix
+}
+
+// currentEnvPrefix returns the prefix set by SetEnvPrefix.
+func currentEnvPrefix() string {
+	envPrefixLock.RLock()
+	defer envPrefixLock.RUnlock()
+	return envPrefix
+}
+
+// envKeyReplacer maps dotted keys to the corresponding environment variable suffix.
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// applyEnvOverrides overrides the settings of the config with those set in the environment.
+func (c *Config) applyEnvOverrides() error {
+	env := newEnvValues()
+	if err := env.Unmarshal(c, withYAMLTags); err != nil {
+		return err
+	}
+	c.env = env
+	return nil
+}
+
+// newEnvValues returns a viper instance exposing the settings overridden in the environment.
+func newEnvValues() *viper.Viper {
+	env := viper.New()
+	env.SetEnvPrefix(currentEnvPrefix())
+	env.SetEnvKeyReplacer(envKeyReplacer)
+	for _, field := range schemaFields() {
+		if !envOverridable(field.Field.Type) {
+			continue
+		}
+		// BindEnv only fails when no key is given.
+		_ = env.BindEnv(field.Key)
+	}
+	return env
+}
+
+// envVarName returns the name of the environment variable overriding the given dotted key.
+func envVarName(key string) string {
+	return currentEnvPrefix() + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+// envOverridable reports whether a setting of the given type can be set from a string.
+func envOverridable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Pointer, reflect.Interface:
+		return false
+	case reflect.Slice:
+		return envOverridable(t.Elem())
+	default:
+		return true
+	}
+}
+
+// withYAMLTags makes viper decode settings using the yaml tags of the Config schema,
+// parsing their string forms with settingDecodeHook.
+func withYAMLTags(dc *mapstructure.DecoderConfig) {
+	dc.TagName = "yaml"
+	dc.DecodeHook = settingDecodeHook()
+}
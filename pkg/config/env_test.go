@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestEnvOverrides(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+		return configPath
+	}
+
+	t.Run("NestedKey", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		configPath := writeConfig(t, "otel:\n  endpoint: file-endpoint:4318\n  sampling-rate: 0.1\n")
+		t.Setenv("TOOLHIVE_OTEL_ENDPOINT", "env-endpoint:4318")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "env-endpoint:4318", config.OTEL.Endpoint)
+		// Settings which are not overridden keep the value from the file
+		assert.Equal(t, 0.1, config.OTEL.SamplingRate)
+		assert.True(t, config.IsSet("otel.endpoint"))
+	})
+
+	t.Run("KeyWithDash", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		configPath := writeConfig(t, "otel:\n  endpoint: localhost:4318\n")
+		t.Setenv("TOOLHIVE_OTEL_SAMPLING_RATE", "0.25")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, 0.25, config.OTEL.SamplingRate)
+		assert.True(t, config.IsSet("otel.sampling-rate"))
+	})
+
+	t.Run("KeyWithUnderscore", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		// SECRETS_PROVIDER_TYPE could be read as secrets.provider.type, but names
+		// are derived from the schema so it resolves to secrets.provider_type.
+		configPath := writeConfig(t, "secrets:\n  provider_type: 1password\n  setup_completed: true\n")
+		t.Setenv("TOOLHIVE_SECRETS_PROVIDER_TYPE", "encrypted")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "encrypted", config.Secrets.ProviderType)
+	})
+
+	t.Run("TopLevelAndList", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		configPath := writeConfig(t, "allow_private_registry_ip: false\n")
+		t.Setenv("TOOLHIVE_ALLOW_PRIVATE_REGISTRY_IP", "true")
+		t.Setenv("TOOLHIVE_CLIENTS_REGISTERED_CLIENTS", "vscode,cursor")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.True(t, config.AllowPrivateRegistryIp)
+		assert.Equal(t, []string{"vscode", "cursor"}, config.Clients.RegisteredClients)
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		configPath := writeConfig(t, "otel:\n  endpoint: localhost:4318\n")
+		t.Setenv("TOOLHIVE_OTEL_SAMPLING_RATE", "often")
+
+		_, err := LoadOrCreateConfigWithPath(configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sampling-rate")
+	})
+
+	t.Run("NotPersistedByUpdate", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		configPath := writeConfig(t, "registry_url: https://file.example.com/registry.json\n")
+		t.Setenv("TOOLHIVE_REGISTRY_URL", "https://env.example.com/registry.json")
+
+		err := UpdateConfigAtPath(configPath, func(c *Config) {
+			c.DefaultGroupMigration = true
+		})
+		require.NoError(t, err)
+
+		config, err := loadOrCreateConfigFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "https://file.example.com/registry.json", config.RegistryUrl)
+		assert.True(t, config.DefaultGroupMigration)
+	})
+
+	t.Run("CustomPrefix", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		SetEnvPrefix("STAGING_")
+		defer SetEnvPrefix(EnvPrefix)
+
+		configPath := writeConfig(t, "otel:\n  endpoint: file-endpoint:4318\n")
+		t.Setenv("STAGING_OTEL_ENDPOINT", "staging-endpoint:4318")
+		t.Setenv("TOOLHIVE_REGISTRY_URL", "https://env.example.com/registry.json")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "staging-endpoint:4318", config.OTEL.Endpoint)
+		// The default prefix no longer applies.
+		assert.Empty(t, config.RegistryUrl)
+		assert.Equal(t, "STAGING_OTEL_ENDPOINT", envVarName("otel.endpoint"))
+	})
+}
+
+func TestEnvVarNamesAreUnique(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]string{}
+	for _, field := range schemaFields() {
+		name := envVarName(field.Key)
+		previous, exists := names[name]
+		assert.False(t, exists, "keys %q and %q both map to %s", previous, field.Key, name)
+		names[name] = field.Key
+	}
+	assert.Equal(t, "TOOLHIVE_SECRETS_PROVIDER_TYPE", envVarName("secrets.provider_type"))
+	assert.Equal(t, "TOOLHIVE_OTEL_SAMPLING_RATE", envVarName("otel.sampling-rate"))
+}
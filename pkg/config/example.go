@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// settingDescriptions describe the settings of the Config schema, and the fields of the entries of
+// list settings, by their dotted key. They are the comments of the document written by WriteExampleConfig.
+var settingDescriptions = map[string]string{
+	"schema_version":             "The version of the config file schema. Set by ToolHive; do not edit.",
+	"secrets":                    "Settings for secrets management.",
+	"secrets.provider_type":      "The secrets provider: encrypted, 1password or none. Set up by thv secret setup.",
+	"secrets.setup_completed":    "Whether the secrets provider has been set up.",
+	"clients":                    "Settings for the MCP clients ToolHive configures.",
+	"clients.registered_clients": "The clients registered with ToolHive, e.g. cursor or vscode.",
+	"clients.auto_discovery":     "Deprecated: kept for migrating older config files only.",
+	"registry_url":               "The URL of a remote registry to use instead of the built-in one.",
+	"local_registry_path":        "The path of a local registry file to use instead of the built-in one.",
+	"allow_private_registry_ip":  "Whether the remote registry may be served from a private IP address.",
+	"ca_certificate_path":        "The path of the CA certificate used for container builds.",
+	"otel":                       "Settings for OpenTelemetry, used when running MCP servers.",
+	"otel.endpoint":              "The OTLP endpoint telemetry is sent to, e.g. localhost:4318.",
+	"otel.sampling-rate":         "The trace sampling rate, from 0.0 to 1.0.",
+	"otel.env-vars":              "The environment variables included in telemetry spans as attributes.",
+	"default_group_migration":    "Whether servers have been migrated to the default group.",
+	"features":                   "Feature flags, mapping the name of each flag to whether it is enabled.",
+	"servers":                    "The MCP servers declared in the config file. Each entry has the settings:",
+	"servers.name":               "The name of the server.",
+	"servers.image":              "The container image the server runs.",
+	"servers.transport":          "The transport of the server, e.g. stdio, sse or streamable-http.",
+	"servers.args":               "The arguments passed to the server.",
+	"timeouts":                   "Timeouts of individual operations, mapping the name of each operation to a duration, e.g. read: 5s.",
+	"validations":                "Rules checked against the other settings when the config is loaded. Each entry has the settings:",
+	"validations.field":          "The dotted key of the setting checked, e.g. otel.sampling-rate.",
+	"validations.operator":       "The comparison: eq, ne, lt, lte, gt, gte, range or in.",
+	"validations.value":          "The value compared against; a [minimum, maximum] list for range, and a list for in.",
+}
+
+// WriteExampleConfig writes an example config file to w, holding every setting of the Config schema
+// at its default value. Each setting is preceded by a comment describing it and annotated with its
+// default, or marked as required. The fields of the entries of list settings, such as servers, are
+// described in the comment of the list. The example can be loaded as a valid config as it is.
+func WriteExampleConfig(w io.Writer) error {
+	mapping, err := exampleMapping(reflect.ValueOf(createNewConfigWithDefaults()), "")
+	if err != nil {
+		return fmt.Errorf("error serializing example config: %w", err)
+	}
+	doc := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: "Example ToolHive config file, holding every setting at its default value.",
+		Content:     []*yaml.Node{mapping},
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("error serializing example config: %w", err)
+	}
+	return encoder.Close()
+}
+
+// exampleMapping returns the node of a section of the example config, whose settings are the fields
+// of value and whose dotted keys start with prefix.
+func exampleMapping(value reflect.Value, prefix string) (*yaml.Node, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := yamlName(field)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: name, HeadComment: settingDescriptions[key]}
+
+		var valueNode *yaml.Node
+		if field.Type.Kind() == reflect.Struct {
+			section, err := exampleMapping(value.Field(i), key+".")
+			if err != nil {
+				return nil, err
+			}
+			valueNode = section
+		} else {
+			valueNode = &yaml.Node{}
+			if err := valueNode.Encode(value.Field(i).Interface()); err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			valueNode.LineComment = exampleAnnotation(field, value.Field(i))
+		}
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			keyNode.HeadComment += "\n" + exampleEntryFields(field.Type.Elem(), key+".")
+		}
+		mapping.Content = append(mapping.Content, keyNode, valueNode)
+	}
+	return mapping, nil
+}
+
+// exampleAnnotation returns the comment following a setting in the example config.
+func exampleAnnotation(field reflect.StructField, value reflect.Value) string {
+	if field.Tag.Get(requiredTag) == "true" {
+		return "required"
+	}
+	switch value.Kind() {
+	case reflect.Slice:
+		return "default: []"
+	case reflect.Map:
+		return "default: {}"
+	}
+	raw, _ := formatSetting(value.Interface())
+	if raw == "" {
+		return `default: ""`
+	}
+	return "default: " + raw
+}
+
+// exampleEntryFields describes the fields of the entries of a list setting, one per line.
+func exampleEntryFields(t reflect.Type, prefix string) string {
+	var lines []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := yamlName(field)
+		if name == "" {
+			continue
+		}
+		line := "  " + name + ": " + settingDescriptions[prefix+name]
+		if field.Tag.Get(requiredTag) == "true" {
+			line += " # required"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
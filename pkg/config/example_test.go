@@ -0,0 +1,52 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestWriteExampleConfig(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExampleConfig(&buf))
+	example := buf.String()
+
+	assert.Contains(t, example, "# Settings for secrets management.\nsecrets:\n")
+	assert.Contains(t, example, "  provider_type: \"\" # default: \"\"\n")
+	assert.Contains(t, example, "allow_private_registry_ip: false # default: false\n")
+	assert.Contains(t, example, "  sampling-rate: 0 # default: 0\n")
+	assert.Contains(t, example, "features: {} # default: {}\n")
+	assert.Contains(t, example, "#   name: The name of the server. # required\n")
+	assert.Contains(t, example, "#   image: The container image the server runs. # required\n")
+	assert.NotContains(t, example, "transport: The transport of the server, e.g. stdio, sse or streamable-http. # required")
+
+	// The example is a valid config as it is.
+	_, configPath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(configPath, buf.Bytes(), 0600))
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, createNewConfigWithDefaults().Secrets, config.Secrets)
+	assert.Empty(t, config.Servers)
+}
+
+func TestSettingDescriptions(t *testing.T) {
+	t.Parallel()
+
+	for _, field := range schemaFields() {
+		assert.NotEmpty(t, settingDescriptions[field.Key], "setting %s has no description", field.Key)
+	}
+	serverType := reflect.TypeOf(ServerConfig{})
+	for i := 0; i < serverType.NumField(); i++ {
+		key := "servers." + yamlName(serverType.Field(i))
+		assert.NotEmpty(t, settingDescriptions[key], "setting %s has no description", key)
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// FeatureEnvPrefix is the prefix of the environment variables used to override feature flags.
+// For example, TOOLHIVE_FEATURE_MY_FLAG=true enables the "my-flag" feature.
+const FeatureEnvPrefix = "TOOLHIVE_FEATURE_"
+
+// knownFeatures maps the names of registered feature flags to their default values.
+var knownFeatures = map[string]bool{}
+
+var featuresLock = &sync.RWMutex{}
+
+// RegisterFeature registers a known feature flag along with its default value.
+// Registering the same flag twice overwrites the previous default.
+func RegisterFeature(name string, defaultValue bool) {
+	featuresLock.Lock()
+	defer featuresLock.Unlock()
+	knownFeatures[name] = defaultValue
+}
+
+// FeatureEnabled reports whether the named feature flag is enabled.
+// The TOOLHIVE_FEATURE_<NAME> environment variable takes precedence, followed by
+// the features section of the config file, and finally the registered default.
+// Querying a flag which has not been registered logs a warning and returns false.
+func (c *Config) FeatureEnabled(name string) bool {
+	featuresLock.RLock()
+	defaultValue, known := knownFeatures[name]
+	featuresLock.RUnlock()
+
+	if !known {
+		logger.Warnf("queried unknown feature flag: %s", name)
+		return false
+	}
+
+	// First check the environment variable
+	envVar := featureEnvVar(name)
+	if envValue, ok := os.LookupEnv(envVar); ok && envValue != "" {
+		enabled, err := parseBool(envValue)
+		if err == nil {
+			return enabled
+		}
+		logger.Warnf("ignoring invalid value for %s: %s", envVar, envValue)
+	}
+
+	// Fall back to config file, then to the registered default
+	if enabled, ok := c.Features[name]; ok {
+		return enabled
+	}
+	return defaultValue
+}
+
+// featureEnvVar returns the name of the environment variable that overrides the given feature flag.
+func featureEnvVar(name string) string {
+	return FeatureEnvPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
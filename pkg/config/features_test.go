@@ -0,0 +1,76 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestFeatureEnabled(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	RegisterFeature("test-default-on", true)
+	RegisterFeature("test-default-off", false)
+
+	t.Run("Defaults", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		c := &Config{}
+		assert.True(t, c.FeatureEnabled("test-default-on"))
+		assert.False(t, c.FeatureEnabled("test-default-off"))
+	})
+
+	t.Run("ConfigOverridesDefault", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		c := &Config{Features: map[string]bool{"test-default-off": true}}
+		assert.True(t, c.FeatureEnabled("test-default-off"))
+	})
+
+	t.Run("LoadedFromConfigFile", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		logger.Initialize()
+		c, err := loadTestConfig(t, `features:
+  test-default-off: true
+`)
+		require.NoError(t, err)
+		assert.True(t, c.FeatureEnabled("test-default-off"))
+		assert.True(t, c.FeatureEnabled("test-default-on"), "flags missing from the section keep their default")
+	})
+
+	t.Run("MissingConfigSection", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		logger.Initialize()
+		c, err := loadTestConfig(t, "registry_url: https://registry.example.com\n")
+		require.NoError(t, err)
+		assert.Empty(t, c.Features)
+		assert.True(t, c.FeatureEnabled("test-default-on"))
+		assert.False(t, c.FeatureEnabled("test-default-off"))
+	})
+
+	t.Run("EnvOverridesConfig", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv("TOOLHIVE_FEATURE_TEST_DEFAULT_OFF", "false")
+		c := &Config{Features: map[string]bool{"test-default-off": true}}
+		assert.False(t, c.FeatureEnabled("test-default-off"))
+
+		t.Setenv("TOOLHIVE_FEATURE_TEST_DEFAULT_ON", "false")
+		assert.False(t, c.FeatureEnabled("test-default-on"))
+	})
+
+	t.Run("InvalidEnvIsIgnored", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv("TOOLHIVE_FEATURE_TEST_DEFAULT_ON", "not-a-bool")
+		c := &Config{}
+		assert.True(t, c.FeatureEnabled("test-default-on"))
+	})
+
+	t.Run("UnknownFlagWarns", func(t *testing.T) { //nolint:paralleltest // Replaces the global logger
+		core, logs := observer.New(zapcore.DebugLevel)
+		defer zap.ReplaceGlobals(zap.New(core))()
+
+		c := &Config{Features: map[string]bool{"test-unknown": true}}
+		assert.False(t, c.FeatureEnabled("test-unknown"))
+
+		warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+		if assert.Len(t, warnings, 1) {
+			assert.Contains(t, warnings[0].Message, "test-unknown")
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileReferencePrefix starts settings which are read from a file rather than holding their
+	// value, e.g. "fromFile:/run/secrets/otel-token", following the Docker secrets convention.
+	FileReferencePrefix = "fromFile:"
+
+	// fileKeySuffix ends the keys of the siblings of string settings which name the file the
+	// setting is read from, e.g. "registry_url_file" for "registry_url".
+	fileKeySuffix = "_file"
+)
+
+// resolveFileReferences reads the string settings which have a sibling _file key, and the string
+// settings and string map values holding a FileReferencePrefix reference, from the files they name.
+// Relative paths are resolved against the directory of the config file, and surrounding whitespace
+// is trimmed. Like resolved secrets, the contents are never rendered, logged or recorded.
+// Settings overridden in the environment are not read from their sibling files.
+func (c *Config) resolveFileReferences() error {
+	value := reflect.ValueOf(c).Elem()
+	for _, field := range schemaFields() {
+		setting := value.FieldByIndex(field.Index)
+		var err error
+		switch {
+		case setting.Kind() == reflect.String:
+			err = c.resolveStringFileReference(field, setting)
+		case setting.Kind() == reflect.Map && setting.Type().Elem().Kind() == reflect.String:
+			err = c.resolveMapFileReferences(field, setting)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) resolveStringFileReference(field schemaField, setting reflect.Value) error {
+	fileKey := field.Key + fileKeySuffix
+	if c.values != nil && c.values.IsSet(fileKey) && (c.env == nil || !c.env.IsSet(field.Key)) {
+		if setting.String() != "" {
+			return fmt.Errorf("%s and %s are mutually exclusive", field.Key, fileKey)
+		}
+		contents, err := readFileReference(c.values.GetString(fileKey), c.dir)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", field.Key, err)
+		}
+		c.secrets = append(c.secrets, resolvedSecret{key: field.Key, index: field.Index})
+		setting.SetString(contents)
+		return nil
+	}
+
+	path, ok := strings.CutPrefix(setting.String(), FileReferencePrefix)
+	if !ok {
+		return nil
+	}
+	contents, err := readFileReference(path, c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", field.Key, err)
+	}
+	c.secrets = append(c.secrets, resolvedSecret{key: field.Key, index: field.Index, reference: setting.String()})
+	setting.SetString(contents)
+	return nil
+}
+
+func (c *Config) resolveMapFileReferences(field schemaField, setting reflect.Value) error {
+	for _, mapKey := range setting.MapKeys() {
+		reference := setting.MapIndex(mapKey).String()
+		path, ok := strings.CutPrefix(reference, FileReferencePrefix)
+		if !ok {
+			continue
+		}
+		key := field.Key + "." + mapKey.String()
+		contents, err := readFileReference(path, c.dir)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		c.secrets = append(c.secrets, resolvedSecret{
+			key: key, index: field.Index, mapKey: mapKey.String(), reference: reference,
+		})
+		setting.SetMapIndex(mapKey, reflect.ValueOf(contents).Convert(setting.Type().Elem()))
+	}
+	return nil
+}
+
+// knownFileKeys returns the sibling _file keys the string settings may be read from.
+func knownFileKeys() []string {
+	var keys []string
+	for _, field := range schemaFields() {
+		if field.Field.Type.Kind() == reflect.String {
+			keys = append(keys, field.Key+fileKeySuffix)
+		}
+	}
+	return keys
+}
+
+// renderFileKeys adds the sibling _file keys of the config file to doc, the rendered mapping node
+// of the config, so that saving a config never drops them. Each is placed after its setting.
+func (c *Config) renderFileKeys(doc *yaml.Node) {
+	if c.values == nil {
+		return
+	}
+	for _, fileKey := range knownFileKeys() {
+		if !c.values.IsSet(fileKey) {
+			continue
+		}
+		segments := strings.Split(fileKey, ".")
+		parent := mappingAt(doc, segments[:len(segments)-1])
+		position := len(parent.Content)
+		settingName := strings.TrimSuffix(segments[len(segments)-1], fileKeySuffix)
+		for i := 0; i < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == settingName {
+				position = i + 2
+			}
+		}
+		entry := []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: segments[len(segments)-1]},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: c.values.GetString(fileKey)},
+		}
+		parent.Content = append(parent.Content[:position], append(entry, parent.Content[position:]...)...)
+	}
+}
+
+// mappingAt returns the mapping node at the given path of keys below node, creating missing
+// mappings, such as sections omitted because all their settings are empty.
+func mappingAt(node *yaml.Node, path []string) *yaml.Node {
+	for _, name := range path {
+		var child *yaml.Node
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				child = node.Content[i+1]
+			}
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, child)
+		}
+		node = child
+	}
+	return node
+}
+
+func readFileReference(path, baseDir string) (string, error) {
+	path, err := normalizePath(strings.TrimSpace(path), baseDir)
+	if err != nil {
+		return "", err
+	}
+	// #nosec G304: The file is referenced by the config file being loaded.
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewFromMap builds a config from the settings in m, keyed as in the config file, without
+// touching the disk. It is meant for tests, which can provide exactly the settings they need:
+//
+//	config, err := NewFromMap(map[string]any{
+//		"registry_url": "https://registry.example.com/registry.json",
+//		"otel":         map[string]any{"endpoint": "localhost:4318"},
+//	})
+//
+// The config is resolved as if it had been loaded from a file: settings of a newer schema version
+// are refused and older ones are migrated, environment overrides apply and the result is validated.
+// Relative paths are left as they are.
+func NewFromMap(m map[string]any) (*Config, error) {
+	// The settings are decoded as those of a config file, rather than merged into viper
+	// directly, since viper lower-cases the keys of map settings such as features.
+	configFile, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize config settings: %w", err)
+	}
+
+	config := createNewConfigWithDefaults()
+	err = config.decode(configFile)
+	if err != nil {
+		return nil, err
+	}
+	err = config.checkSchemaVersion("config settings")
+	if err != nil {
+		return nil, err
+	}
+	config.migrateSchemaInMemory()
+
+	err = config.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PopulatesFields", func(t *testing.T) {
+		t.Parallel()
+		config, err := NewFromMap(map[string]any{
+			"registry_url": "https://registry.example.com/registry.json",
+			"otel": map[string]any{
+				"endpoint":      "localhost:4318",
+				"sampling-rate": 0.5,
+			},
+			"features": map[string]any{"RemoteRegistry": true},
+			"servers": []any{
+				map[string]any{"name": "fetch", "image": "ghcr.io/stackloklabs/fetch:latest"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://registry.example.com/registry.json", config.RegistryUrl)
+		assert.Equal(t, "localhost:4318", config.OTEL.Endpoint)
+		assert.Equal(t, 0.5, config.OTEL.SamplingRate)
+		assert.Equal(t, map[string]bool{"RemoteRegistry": true}, config.Features)
+		assert.Equal(t, []ServerConfig{{Name: "fetch", Image: "ghcr.io/stackloklabs/fetch:latest"}}, config.Servers)
+		assert.True(t, config.IsSet("otel.sampling-rate"))
+		assert.False(t, config.IsSet("ca_certificate_path"))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+		config, err := NewFromMap(nil)
+		require.NoError(t, err)
+		assert.Equal(t, createNewConfigWithDefaults().RegistryUrl, config.RegistryUrl)
+	})
+
+	t.Run("SchemaVersion", func(t *testing.T) {
+		t.Parallel()
+		config, err := NewFromMap(map[string]any{
+			"schema_version": 0,
+			"secrets":        map[string]any{"provider_type": "encrypted"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion)
+		assert.True(t, config.Secrets.SetupCompleted, "older settings are migrated")
+
+		_, err = NewFromMap(map[string]any{"schema_version": CurrentSchemaVersion + 1})
+		require.ErrorIs(t, err, ErrConfigTooNew)
+	})
+
+	t.Run("FailsValidation", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewFromMap(map[string]any{
+			"servers": []any{map[string]any{"name": "fetch"}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "servers[0].image: must not be empty")
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewFromMap(map[string]any{"otel": "localhost:4318"})
+		require.Error(t, err)
+	})
+}
+
+func TestNewFromMapDoesNotSave(t *testing.T) { //nolint:paralleltest // Replaces the default config path
+	const content = "registry_url: https://default.example.com/registry.json\n"
+	defaultPath := SetupTestDefaultConfigPath(t, content)
+
+	config, err := NewFromMap(map[string]any{
+		"schema_version": 0,
+		"secrets":        map[string]any{"provider_type": "basic"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "encrypted", config.Secrets.ProviderType, "older settings are migrated")
+
+	data, err := os.ReadFile(defaultPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data), "the default config file is left as it is")
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// Hash returns a fingerprint of the effective settings of c, for detecting drift between configs or
+// busting caches derived from them. It is the hex-encoded SHA-256 of the settings keyed by their
+// dotted keys in sorted order, so it does not depend on the order of the config file or on how the
+// config was loaded: configs with equal settings always hash the same, as Diff would report no change.
+// Empty and absent lists or maps are considered equal. Resolved secrets are hashed as the references
+// they were resolved from, so that the hash neither changes when a secret is rotated nor reveals it.
+// Where a config was loaded from is not part of the hash.
+func (c *Config) Hash() string {
+	value := reflect.ValueOf(c.withSecretReferences()).Elem()
+	settings := make(map[string]any, len(schemaFields()))
+	for _, field := range schemaFields() {
+		setting := value.FieldByIndex(field.Index)
+		if kind := setting.Kind(); (kind == reflect.Slice || kind == reflect.Map) && setting.Len() == 0 {
+			settings[field.Key] = nil
+			continue
+		}
+		settings[field.Key] = setting.Interface()
+	}
+
+	// encoding/json sorts the keys of maps, including those of map settings.
+	// Marshaling cannot fail, as settings only hold plain values.
+	canonical, _ := json.Marshal(settings)
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	config, err := loadTestConfig(t, `registry_url: https://example.com/registry.json
+otel:
+  endpoint: localhost:4318
+features:
+  beta: true
+  groups: false
+`)
+	require.NoError(t, err)
+	// The same settings, in a different order and loaded from a directory of fragments.
+	reordered, err := loadTestConfig(t, `features:
+  groups: false
+  beta: true
+otel:
+  endpoint: localhost:4318
+registry_url: https://example.com/registry.json
+`)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10-otel.yaml"),
+		[]byte("otel:\n  endpoint: localhost:4318\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20-rest.yaml"),
+		[]byte("registry_url: https://example.com/registry.json\nfeatures:\n  groups: false\n  beta: true\n"), 0600))
+	fromDir, err := LoadFromDir(dir)
+	require.NoError(t, err)
+
+	hash := config.Hash()
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, config.Hash(), "the hash must be stable")
+	assert.Equal(t, hash, reordered.Hash())
+	assert.Equal(t, hash, fromDir.Hash())
+
+	changed := config.clone()
+	changed.OTEL.Endpoint = "localhost:4317"
+	assert.NotEqual(t, hash, changed.Hash())
+
+	// Empty and absent lists are equal.
+	assert.Equal(t, (&Config{}).Hash(), (&Config{Servers: []ServerConfig{}, Features: map[string]bool{}}).Hash())
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigJSONEnvVar is the environment variable which, when set, holds settings as a JSON object keyed
+// as in the config file, for environments where passing a single variable is easier than providing
+// a file. The settings are merged over those of the config file, as LoadWithOverlay merges an overlay:
+// nested sections are merged key by key, while lists are replaced as a whole. The individual
+// environment overrides described by EnvPrefix still take precedence over them. Settings provided
+// through it are reported by SourceOf as SourceFile, and are never written to the config file.
+//
+//nolint:revive // Intentionally named ConfigJSONEnvVar despite package name, after the variable it names
+const ConfigJSONEnvVar = "TOOLHIVE_CONFIG_JSON"
+
+// applyConfigJSON merges the settings held by ConfigJSONEnvVar, if any, over those of the config
+// file c was loaded from and of the given overrides.
+func (c *Config) applyConfigJSON(overrides ...settingsOverride) error {
+	if raw := os.Getenv(ConfigJSONEnvVar); raw != "" {
+		var override map[string]any
+		if err := json.Unmarshal([]byte(raw), &override); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", ConfigJSONEnvVar, err)
+		}
+		overrides = append(overrides, settingsOverride{source: ConfigJSONEnvVar, settings: override})
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	return c.mergeOverrides(overrides)
+}
+
+// settingsOverride holds settings merged over those of a config file, and where they were read from.
+type settingsOverride struct {
+	source   string
+	settings map[string]any
+}
+
+// mergeOverrides merges each of overrides in order over the settings of the config file c was loaded
+// from, and replaces c with the result. Overrides of a newer schema version are refused, and the
+// result is migrated in memory, so that the overrides are never saved.
+func (c *Config) mergeOverrides(overrides []settingsOverride) error {
+	merged := map[string]any{}
+	// #nosec G304: The file is the one the config was just loaded from.
+	data, err := os.ReadFile(c.file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to read config file %s: %w", c.file, err)
+	}
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return fmt.Errorf("failed to parse config file yaml %s: %w", c.file, err)
+	}
+	if merged == nil {
+		merged = map[string]any{}
+	}
+	for _, override := range overrides {
+		if err := override.checkSchemaVersion(); err != nil {
+			return err
+		}
+		mergeValues(merged, override.settings)
+	}
+
+	configFile, err := yaml.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", overrides[len(overrides)-1].source, err)
+	}
+	config := createNewConfigWithDefaults()
+	if err := config.decode(configFile); err != nil {
+		return err
+	}
+	config.migrateSchemaInMemory()
+	config.dir, config.file = c.dir, c.file
+	*c = config
+	return nil
+}
+
+// checkSchemaVersion refuses overrides of a newer schema version than CurrentSchemaVersion.
+func (o settingsOverride) checkSchemaVersion() error {
+	data, err := yaml.Marshal(o.settings)
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", o.source, err)
+	}
+	var config Config
+	if err := config.decode(data); err != nil {
+		return fmt.Errorf("invalid settings in %s: %w", o.source, err)
+	}
+	return config.checkSchemaVersion(o.source)
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestConfigJSONEnvVar(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+
+	t.Run("Settings", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte("allow_private_registry_ip: true\n"), 0600))
+		t.Setenv(ConfigJSONEnvVar, `{
+			"registry_url": "https://json.example.com/registry.json",
+			"otel": {"endpoint": "localhost:4318", "sampling-rate": 0.5},
+			"features": {"beta": true},
+			"servers": [{"name": "fetch", "image": "ghcr.io/stackloklabs/gofetch/server:latest"}]
+		}`)
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "https://json.example.com/registry.json", config.RegistryUrl)
+		assert.True(t, config.AllowPrivateRegistryIp)
+		assert.Equal(t, OpenTelemetryConfig{Endpoint: "localhost:4318", SamplingRate: 0.5}, config.OTEL)
+		assert.Equal(t, map[string]bool{"beta": true}, config.Features)
+		assert.Equal(t, []ServerConfig{{Name: "fetch", Image: "ghcr.io/stackloklabs/gofetch/server:latest"}}, config.Servers)
+
+		// The settings are never written to the config file.
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "json.example.com")
+	})
+
+	t.Run("PrecedenceOverFile", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte(`registry_url: https://file.example.com/registry.json
+otel:
+  endpoint: file-endpoint:4318
+  sampling-rate: 0.1
+`), 0600))
+		t.Setenv(ConfigJSONEnvVar, `{"registry_url": "https://json.example.com/registry.json", "otel": {"endpoint": "json-endpoint:4318"}}`)
+		t.Setenv("TOOLHIVE_OTEL_ENDPOINT", "env-endpoint:4318")
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "https://json.example.com/registry.json", config.RegistryUrl)
+		// Sections are merged key by key, so settings only in the file are kept.
+		assert.Equal(t, 0.1, config.OTEL.SamplingRate)
+		// Individual environment overrides take precedence over the JSON settings.
+		assert.Equal(t, "env-endpoint:4318", config.OTEL.Endpoint)
+	})
+
+	t.Run("MigratedWithoutSaving", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		const content = "registry_url: https://file.example.com/registry.json\n"
+		configPath := SetupTestDefaultConfigPath(t, content)
+		t.Setenv(ConfigJSONEnvVar, `{"secrets": {"provider_type": "basic"}}`)
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "encrypted", config.Secrets.ProviderType, "older settings are migrated")
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("Invalid", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		t.Setenv(ConfigJSONEnvVar, `{"registry_url": `)
+
+		_, err := LoadOrCreateConfigWithPath(configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse "+ConfigJSONEnvVar)
+	})
+}
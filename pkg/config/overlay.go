@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadWithOverlay loads the config at basePath and then applies the environment-specific overlay
+// file at overlayPath on top of it, in two phases:
+//
+//  1. The base config is loaded exactly as LoadOrCreateConfigWithPath does, including its full
+//     validation, and an invalid base config is an error regardless of the overlay.
+//  2. The overlay is merged into the settings of the base file, as LoadFromDir merges fragments:
+//     nested sections are merged key by key, while lists are replaced as a whole. The merged
+//     settings are migrated as the base file is, and ConfigJSONEnvVar and the environment
+//     overrides are then applied on top of them, secret references are resolved, and the result
+//     is validated by validate rather than by Validate, so that an overlay may relax checks which
+//     the base config must pass. Validation is skipped if validate is nil.
+//
+// Relative paths in either file are resolved against the directory of the base file. The merged
+// config is returned, and nothing is written to disk.
+func LoadWithOverlay(basePath, overlayPath string, validate Validator) (*Config, error) {
+	_, err := LoadOrCreateConfigWithPath(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base config: %w", err)
+	}
+
+	config, err := loadOrCreateConfigFile(basePath)
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G304: The overlay file is provided by the caller.
+	data, err := os.ReadFile(overlayPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %s: %w", overlayPath, err)
+	}
+	var overlay map[string]any
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		return nil, fmt.Errorf("failed to parse config file yaml %s: %w", overlayPath, err)
+	}
+
+	err = config.applyConfigJSON(settingsOverride{source: overlayPath, settings: overlay})
+	if err != nil {
+		return nil, err
+	}
+	err = config.resolveWith(validate)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
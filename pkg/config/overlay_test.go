@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestLoadWithOverlay(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	const base = `registry_url: https://registry.example.com/registry.json
+otel:
+  endpoint: otel.example.com:4318
+features:
+  groups: true
+servers:
+  - name: fetch
+    image: ghcr.io/stackloklabs/fetch:latest
+`
+	// The overlay declares a server without an image, which Validate rejects, as the
+	// image is provided by the environment's own registry.
+	const overlay = `registry_url: https://staging.example.com/registry.json
+features:
+  remote_registry: true
+servers:
+  - name: fetch
+`
+	// requireNames only checks what the overlay is expected to provide.
+	requireNames := func(c *Config) error {
+		for _, server := range c.Servers {
+			if server.Name == "" {
+				return errors.New("servers: name must not be empty")
+			}
+		}
+		return nil
+	}
+
+	writeOverlay := func(t *testing.T, content string) (string, string) {
+		t.Helper()
+		_, basePath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(basePath, []byte(base), 0600))
+		overlayPath := filepath.Join(filepath.Dir(basePath), "staging.yaml")
+		require.NoError(t, os.WriteFile(overlayPath, []byte(content), 0600))
+		return basePath, overlayPath
+	}
+
+	t.Run("OverlayRelaxesValidation", func(t *testing.T) {
+		t.Parallel()
+		basePath, overlayPath := writeOverlay(t, overlay)
+
+		config, err := LoadWithOverlay(basePath, overlayPath, requireNames)
+		require.NoError(t, err)
+		assert.Equal(t, "https://staging.example.com/registry.json", config.RegistryUrl)
+		assert.Equal(t, "otel.example.com:4318", config.OTEL.Endpoint)
+		assert.Equal(t, map[string]bool{"groups": true, "remote_registry": true}, config.Features)
+		assert.Equal(t, []ServerConfig{{Name: "fetch"}}, config.Servers)
+		assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion, "the merged settings are migrated")
+
+		// The merged config would not pass the base validation.
+		require.Error(t, config.Validate())
+	})
+
+	t.Run("OverlayValidationFails", func(t *testing.T) {
+		t.Parallel()
+		basePath, overlayPath := writeOverlay(t, "servers:\n  - image: ghcr.io/stackloklabs/fetch:latest\n")
+
+		_, err := LoadWithOverlay(basePath, overlayPath, requireNames)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "servers: name must not be empty")
+	})
+
+	t.Run("OverlayTooNew", func(t *testing.T) {
+		t.Parallel()
+		basePath, overlayPath := writeOverlay(t, "schema_version: 99\n")
+
+		_, err := LoadWithOverlay(basePath, overlayPath, requireNames)
+		require.ErrorIs(t, err, ErrConfigTooNew)
+		assert.Contains(t, err.Error(), overlayPath+" has schema version 99")
+	})
+
+	t.Run("InvalidBaseFails", func(t *testing.T) {
+		t.Parallel()
+		basePath, overlayPath := writeOverlay(t, overlay)
+		require.NoError(t, os.WriteFile(basePath, []byte("servers:\n  - name: fetch\n"), 0600))
+
+		_, err := LoadWithOverlay(basePath, overlayPath, requireNames)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid base config")
+	})
+
+	t.Run("NilValidatorSkipsValidation", func(t *testing.T) {
+		t.Parallel()
+		basePath, overlayPath := writeOverlay(t, "servers:\n  - image: ghcr.io/stackloklabs/fetch:latest\n")
+
+		config, err := LoadWithOverlay(basePath, overlayPath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []ServerConfig{{Image: "ghcr.io/stackloklabs/fetch:latest"}}, config.Servers)
+	})
+}
+
+func TestLoadWithOverlayConfigJSON(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+	t.Setenv(ConfigJSONEnvVar, `{"registry_url": "https://json.example.com/registry.json", "otel": {"sampling-rate": 0.5}}`)
+
+	_, basePath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(basePath, []byte("otel:\n  endpoint: otel.example.com:4318\n"), 0600))
+	overlayPath := filepath.Join(filepath.Dir(basePath), "staging.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, []byte(`registry_url: https://staging.example.com/registry.json
+otel:
+  endpoint: staging.example.com:4318
+`), 0600))
+
+	config, err := LoadWithOverlay(basePath, overlayPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://json.example.com/registry.json", config.RegistryUrl,
+		"settings from the environment override the overlay")
+	assert.Equal(t, "staging.example.com:4318", config.OTEL.Endpoint)
+	assert.Equal(t, 0.5, config.OTEL.SamplingRate)
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// Struct tags marking string settings which hold file paths. Paths tagged path:"true" or
+// path_must_exist:"true" are normalized when the config is loaded: a leading ~ is expanded to the
+// user's home directory, and relative paths are resolved against the directory of the config file.
+// Paths tagged path_must_exist must additionally point to an existing file or directory.
+// Settings which held paths before normalization was introduced are tagged path:"home" instead:
+// only a leading ~ is expanded, so that relative paths keep resolving against the working directory.
+const (
+	pathTag          = "path"
+	pathMustExistTag = "path_must_exist"
+	pathHome         = "home"
+)
+
+// CheckReferences checks that every path setting of c, such as ca_certificate_path, references an
+// existing and readable file or directory, returning one error per offending setting, prefixed with
+// its key. Unlike Validate, the result depends on the environment the config is used in rather than
+// on the config alone, so it is not run when loading the config; run it to detect such problems early.
+func (c *Config) CheckReferences() []error {
+	value := reflect.ValueOf(c).Elem()
+
+	var errs []error
+	for _, field := range schemaFields() {
+		if field.Field.Tag.Get(pathTag) == "" && field.Field.Tag.Get(pathMustExistTag) != "true" {
+			continue
+		}
+		setting := value.FieldByIndex(field.Index)
+		if setting.Kind() != reflect.String || setting.String() == "" {
+			continue
+		}
+		if err := checkReadable(setting.String()); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field.Key, err))
+		}
+	}
+	return errs
+}
+
+// normalizePaths normalizes the path settings of c against the directory it was loaded from.
+func (c *Config) normalizePaths() error {
+	return normalizePaths(c, c.dir)
+}
+
+// normalizePaths decodes the path settings of the struct pointed to by v back into it, normalizing
+// them with pathDecodeHook. Every missing path tagged path_must_exist is reported, keyed by its setting.
+func normalizePaths(v any, baseDir string) error {
+	dc := &mapstructure.DecoderConfig{Result: v}
+	withPathNormalization(baseDir)(dc)
+	decoder, err := mapstructure.NewDecoder(dc)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(pathSettings(reflect.ValueOf(v).Elem()))
+	var decodeErr *mapstructure.DecodeError
+	if !errors.As(err, &decodeErr) {
+		return err
+	}
+	if decodeErr.Name() == "" {
+		return decodeErr.Unwrap()
+	}
+	return fmt.Errorf("%s.%w", decodeErr.Name(), decodeErr.Unwrap())
+}
+
+// withPathNormalization makes mapstructure decode settings using the yaml tags of the Config
+// schema, normalizing the path settings against baseDir with pathDecodeHook.
+func withPathNormalization(baseDir string) func(*mapstructure.DecoderConfig) {
+	return func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+		dc.DecodeHook = pathDecodeHook(baseDir)
+	}
+}
+
+// pathSettings returns the non-empty path settings of value as nested maps, keyed by their yaml names.
+func pathSettings(value reflect.Value) map[string]any {
+	settings := map[string]any{}
+	for _, field := range collectSchemaFields(value.Type(), "", nil) {
+		if field.Field.Tag.Get(pathTag) == "" && field.Field.Tag.Get(pathMustExistTag) != "true" {
+			continue
+		}
+		setting := value.FieldByIndex(field.Index)
+		if setting.Kind() != reflect.String || setting.String() == "" {
+			continue
+		}
+
+		section := settings
+		names := strings.Split(field.Key, ".")
+		for _, name := range names[:len(names)-1] {
+			if _, ok := section[name].(map[string]any); !ok {
+				section[name] = map[string]any{}
+			}
+			section = section[name].(map[string]any)
+		}
+		section[names[len(names)-1]] = setting.String()
+	}
+	return settings
+}
+
+// pathDecodeHook returns a decode hook which normalizes the path settings of each struct decoded
+// from a map: a leading ~ is expanded, and relative paths are resolved against baseDir unless they
+// are tagged path:"home". Every missing path tagged path_must_exist is reported, keyed by its setting.
+func pathDecodeHook(baseDir string) mapstructure.DecodeHookFuncType {
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		settings, ok := data.(map[string]any)
+		if !ok || to.Kind() != reflect.Struct {
+			return data, nil
+		}
+
+		normalized := make(map[string]any, len(settings))
+		for name, setting := range settings {
+			normalized[name] = setting
+		}
+
+		var errs []error
+		for i := 0; i < to.NumField(); i++ {
+			field := to.Field(i)
+			mode := field.Tag.Get(pathTag)
+			mustExist := field.Tag.Get(pathMustExistTag) == "true"
+			if mode == "" && !mustExist {
+				continue
+			}
+			name := yamlName(field)
+			setting, ok := normalized[name].(string)
+			if !ok || setting == "" {
+				continue
+			}
+
+			dir := baseDir
+			if mode == pathHome {
+				dir = ""
+			}
+			path, err := normalizePath(setting, dir)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			normalized[name] = path
+
+			if mustExist {
+				if _, err := os.Stat(path); err != nil {
+					errs = append(errs, fmt.Errorf("%s: path %s does not exist or is not accessible: %w", name, path, err))
+				}
+			}
+		}
+		return normalized, errors.Join(errs...)
+	}
+}
+
+// normalizePath expands a leading ~ in path and resolves it against baseDir if it is relative.
+// Relative paths are kept relative if baseDir is empty.
+func normalizePath(path, baseDir string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to expand ~ in %s: %w", path, err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) && baseDir != "" {
+		path = filepath.Join(baseDir, path)
+	}
+	return filepath.Clean(path), nil
+}
+
+// checkReadable checks that path references an existing file or directory which can be read.
+func checkReadable(path string) error {
+	// #nosec G304: The path is a setting of the config being checked.
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s does not exist", path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not accessible: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestLoadNormalizesPaths(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		content           string
+		localRegistryPath string
+		caCertificatePath string
+		otelEndpoint      string
+	}{
+		{
+			name: "TildePath",
+			content: `local_registry_path: ~/registries/local.json
+ca_certificate_path: ~/certs/ca.pem
+`,
+			localRegistryPath: filepath.Join(home, "registries", "local.json"),
+			caCertificatePath: filepath.Join(home, "certs", "ca.pem"),
+		},
+		{
+			// Settings which held paths before normalization was introduced keep resolving against the working directory.
+			name: "RelativePath",
+			content: `local_registry_path: ./registries/local.json
+ca_certificate_path: certs/ca.pem
+`,
+			localRegistryPath: filepath.Join("registries", "local.json"),
+			caCertificatePath: filepath.Join("certs", "ca.pem"),
+		},
+		{
+			name: "AbsoluteAndUntaggedUnchanged",
+			content: `local_registry_path: /var/lib/toolhive/registry.json
+otel:
+  endpoint: ~/not-a-path
+`,
+			localRegistryPath: "/var/lib/toolhive/registry.json",
+			otelEndpoint:      "~/not-a-path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config, err := loadTestConfig(t, tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.localRegistryPath, config.LocalRegistryPath)
+			assert.Equal(t, tt.caCertificatePath, config.CACertificatePath)
+			assert.Equal(t, tt.otelEndpoint, config.OTEL.Endpoint)
+		})
+	}
+}
+
+func TestPathDecodeHook(t *testing.T) {
+	t.Parallel()
+
+	// No Config setting resolves relative paths against the config directory or must exist yet.
+	type settings struct {
+		Data     string `yaml:"data" path:"true"`
+		Required string `yaml:"required" path_must_exist:"true"`
+	}
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	baseDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "present.json"), []byte("{}"), 0600))
+
+	tests := []struct {
+		name     string
+		settings settings
+		expected settings
+		errMsg   string
+	}{
+		{
+			name:     "TildePath",
+			settings: settings{Data: "~/toolhive/data"},
+			expected: settings{Data: filepath.Join(home, "toolhive", "data")},
+		},
+		{
+			name:     "RelativePath",
+			settings: settings{Data: "./data/../cache", Required: "present.json"},
+			expected: settings{Data: filepath.Join(baseDir, "cache"), Required: filepath.Join(baseDir, "present.json")},
+		},
+		{
+			name:     "MissingRequiredPath",
+			settings: settings{Required: "missing.json"},
+			errMsg:   "required: path " + filepath.Join(baseDir, "missing.json") + " does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := tt.settings
+
+			err := normalizePaths(&s, baseDir)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s)
+		})
+	}
+}
+
+func TestCheckReferences(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(certPath, []byte("certificate"), 0600))
+
+	t.Run("Present", func(t *testing.T) {
+		t.Parallel()
+		config := &Config{CACertificatePath: certPath, LocalRegistryPath: dir}
+		assert.Empty(t, config.CheckReferences())
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		missing := filepath.Join(t.TempDir(), "certs", "missing.pem")
+		require.NoError(t, os.WriteFile(configPath, []byte("ca_certificate_path: "+missing+"\n"), 0600))
+
+		// Missing references do not prevent loading the config.
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+
+		errs := config.CheckReferences()
+		require.Len(t, errs, 1)
+		assert.Equal(t, "ca_certificate_path: "+missing+" does not exist", errs[0].Error())
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, (&Config{}).CheckReferences())
+	})
+}
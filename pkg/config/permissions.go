@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// PermStrictEnvVar is the environment variable which, when set to true, makes loading a config
+// file which holds secrets and is readable by other users fail, rather than only warning about it.
+const PermStrictEnvVar = "TOOLHIVE_CONFIG_PERM_STRICT"
+
+// checkFilePermissions reports a config file which is readable by its group or by other users
+// while holding the value of a sensitive setting. In strict mode it is an error, otherwise a warning.
+// Settings holding secret references are not secrets themselves. Windows file modes do not
+// reflect access control lists, so the check is skipped there.
+func (c *Config) checkFilePermissions(configPath string, info fs.FileInfo) error {
+	return checkSecretPermissions(configPath, info, sensitiveKeysHoldingSecrets(reflect.ValueOf(c).Elem()))
+}
+
+// checkSecretPermissions reports a config file which is readable by its group or by other users
+// while the settings at the given keys hold secrets.
+func checkSecretPermissions(configPath string, info fs.FileInfo, keys []string) error {
+	if runtime.GOOS == "windows" || info.Mode().Perm()&0044 == 0 {
+		return nil
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("config file %s holds secrets (%s) but is readable by other users (mode %04o); restrict it with chmod 600",
+		configPath, strings.Join(keys, ", "), info.Mode().Perm())
+	if permStrictMode() {
+		return fmt.Errorf("insecure config file permissions: %s", msg)
+	}
+	logger.Warn(msg)
+	return nil
+}
+
+// sensitiveKeysHoldingSecrets returns the dotted keys of the sensitive settings of the struct value
+// which hold a value other than a secret reference, or a URL embedding credentials for those tagged
+// sensitiveUserinfo, in schema order.
+func sensitiveKeysHoldingSecrets(value reflect.Value) []string {
+	var keys []string
+	for _, field := range collectSchemaFields(value.Type(), "", nil) {
+		setting := value.FieldByIndex(field.Index)
+		switch field.Field.Tag.Get(sensitiveTag) {
+		case "true":
+			if holdsSecret(setting) {
+				keys = append(keys, field.Key)
+			}
+		case sensitiveUserinfo:
+			if setting.Kind() == reflect.String && embedsCredentials(setting.String()) {
+				keys = append(keys, field.Key)
+			}
+		}
+	}
+	return keys
+}
+
+func holdsSecret(setting reflect.Value) bool {
+	switch setting.Kind() {
+	case reflect.String:
+		return setting.String() != "" && !strings.HasPrefix(setting.String(), SecretReferencePrefix)
+	case reflect.Map:
+		for _, key := range setting.MapKeys() {
+			if holdsSecret(setting.MapIndex(key)) {
+				return true
+			}
+		}
+		return false
+	default:
+		return !setting.IsZero()
+	}
+}
+
+// permStrictMode reports whether strict permission checks have been enabled through PermStrictEnvVar.
+func permStrictMode() bool {
+	strict, err := strconv.ParseBool(os.Getenv(PermStrictEnvVar))
+	return err == nil && strict
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	// maxTag names the sibling setting a numeric setting must not exceed, e.g. max:"max_open_conns".
+	maxTag = "max"
+	// minTag names the sibling setting a numeric setting must not be below, e.g. min:"min_replicas".
+	minTag = "min"
+)
+
+// validateRelations returns the numeric settings of the config which are out of the range set by
+// the sibling settings named in their max and min tags.
+func (c *Config) validateRelations() []error {
+	return relationErrors(reflect.ValueOf(c).Elem(), "")
+}
+
+// relationErrors checks the max and min tags of the fields of the struct v and of its nested
+// sections, each error naming both settings by their dotted key. Settings left at zero are not
+// compared, as zero means the setting is unset and its default applies.
+func relationErrors(v reflect.Value, prefix string) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := yamlName(field)
+		if name == "" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			errs = append(errs, relationErrors(v.Field(i), prefix+name+".")...)
+			continue
+		}
+
+		for _, tag := range []string{maxTag, minTag} {
+			if err := checkRelation(v, i, tag, prefix); err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", prefix, name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// checkRelation checks the field i of the struct v, whose settings are keyed under prefix, against
+// the sibling setting named by its tag, if it has one.
+func checkRelation(v reflect.Value, i int, tag, prefix string) error {
+	name := v.Type().Field(i).Tag.Get(tag)
+	if name == "" {
+		return nil
+	}
+	bound := prefix + name
+	other, ok := siblingSetting(v, name)
+	if !ok {
+		return fmt.Errorf("%s names unknown setting %s", tag, bound)
+	}
+	value, ok := ruleNumber(v.Field(i).Interface())
+	limit, limitOK := ruleNumber(other.Interface())
+	if !ok || !limitOK || v.Field(i).Kind() == reflect.String || other.Kind() == reflect.String {
+		return fmt.Errorf("%s relates settings which are not numbers", tag)
+	}
+	if value == 0 || limit == 0 {
+		return nil
+	}
+	if tag == maxTag && value > limit {
+		return fmt.Errorf("must not exceed %s (%v > %v)", bound, v.Field(i).Interface(), other.Interface())
+	}
+	if tag == minTag && value < limit {
+		return fmt.Errorf("must not be below %s (%v < %v)", bound, v.Field(i).Interface(), other.Interface())
+	}
+	return nil
+}
+
+// siblingSetting returns the field of the struct v serialized under the given name.
+func siblingSetting(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if yamlName(v.Type().Field(i)) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
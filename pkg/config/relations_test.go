@@ -0,0 +1,63 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// poolSettings declares settings related through max and min tags, as Config sections would.
+type poolSettings struct {
+	Database struct {
+		MaxOpenConns int `yaml:"max_open_conns"`
+		MaxIdleConns int `yaml:"max_idle_conns" max:"max_open_conns"`
+		MinIdleConns int `yaml:"min_idle_conns,omitempty" max:"max_idle_conns"`
+	} `yaml:"database"`
+}
+
+// brokenRelations declares relations which cannot be checked.
+type brokenRelations struct {
+	Timeout   string `yaml:"timeout"`
+	Broken    int    `yaml:"broken" min:"missing"`
+	NotNumber int    `yaml:"not_number" min:"timeout"`
+}
+
+func TestRelationErrors(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T, content string) []error {
+		t.Helper()
+		var settings poolSettings
+		require.NoError(t, yaml.Unmarshal([]byte(content), &settings))
+		return relationErrors(reflect.ValueOf(settings), "")
+	}
+
+	t.Run("IdleExceedsOpen", func(t *testing.T) {
+		t.Parallel()
+		errs := load(t, "database:\n  max_open_conns: 10\n  max_idle_conns: 20\n")
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "database.max_idle_conns: must not exceed database.max_open_conns (20 > 10)")
+	})
+
+	t.Run("ValidPair", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, load(t, "database:\n  max_open_conns: 10\n  max_idle_conns: 10\n  min_idle_conns: 2\n"))
+	})
+
+	t.Run("UnsetBound", func(t *testing.T) {
+		t.Parallel()
+		assert.Empty(t, load(t, "database:\n  max_idle_conns: 20\n"), "settings left at zero are not compared")
+	})
+
+	t.Run("InvalidTags", func(t *testing.T) {
+		t.Parallel()
+		settings := brokenRelations{Timeout: "5s", Broken: 1, NotNumber: 1}
+		errs := relationErrors(reflect.ValueOf(settings), "")
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "broken: min names unknown setting missing")
+		assert.EqualError(t, errs[1], "not_number: min relates settings which are not numbers")
+	})
+}
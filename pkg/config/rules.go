@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ValidationRule is an invariant declared in the validations section of the config file, which
+// Validate checks against the setting at Field, a dotted key such as otel.sampling-rate:
+//
+//	validations:
+//	  - field: otel.sampling-rate
+//	    operator: range
+//	    value: [0.1, 0.5]
+//
+// The operators eq and ne compare the setting with Value; lt, lte, gt and gte compare it with a
+// number, and range with a list of the inclusive minimum and maximum. Durations may be given as
+// strings such as 30s. The operator in checks that the setting is one of a list of values.
+type ValidationRule struct {
+	Field    string `yaml:"field" required:"true"`
+	Operator string `yaml:"operator" required:"true"`
+	Value    any    `yaml:"value"`
+}
+
+// ruleOperators evaluate the operators of validation rules, returning the violation if the setting
+// does not satisfy the rule, or nil.
+var ruleOperators = map[string]func(setting, value any) error{
+	"eq": func(setting, value any) error {
+		if !equalRuleValues(setting, value) {
+			return fmt.Errorf("must be %v, got %v", value, setting)
+		}
+		return nil
+	},
+	"ne": func(setting, value any) error {
+		if equalRuleValues(setting, value) {
+			return fmt.Errorf("must not be %v", value)
+		}
+		return nil
+	},
+	"lt":    compareRule("less than", func(a, b float64) bool { return a < b }),
+	"lte":   compareRule("at most", func(a, b float64) bool { return a <= b }),
+	"gt":    compareRule("greater than", func(a, b float64) bool { return a > b }),
+	"gte":   compareRule("at least", func(a, b float64) bool { return a >= b }),
+	"range": rangeRule,
+	"in": func(setting, value any) error {
+		for _, allowed := range value.([]any) {
+			if equalRuleValues(setting, allowed) {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %v, got %v", value, setting)
+	},
+}
+
+// check reports whether the rule can be evaluated: its field and operator are set, its operator is supported, its field is a setting
+// of the Config schema, and its value has the shape the operator expects.
+func (r *ValidationRule) check() error {
+	if errs := requiredErrors(reflect.ValueOf(r).Elem(), ""); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if _, ok := ruleOperators[r.Operator]; !ok {
+		return fmt.Errorf("unsupported operator %q", r.Operator)
+	}
+	if !isSchemaKey(r.Field) {
+		return fmt.Errorf("unknown config key: %s", r.Field)
+	}
+
+	switch r.Operator {
+	case "lt", "lte", "gt", "gte":
+		if _, ok := ruleNumber(r.Value); !ok {
+			return fmt.Errorf("operator %s expects a number, got %v", r.Operator, r.Value)
+		}
+	case "range":
+		bounds, ok := r.Value.([]any)
+		if !ok || len(bounds) != 2 {
+			return fmt.Errorf("operator range expects a list of a minimum and a maximum, got %v", r.Value)
+		}
+		for _, bound := range bounds {
+			if _, ok := ruleNumber(bound); !ok {
+				return fmt.Errorf("operator range expects numbers, got %v", bound)
+			}
+		}
+	case "in":
+		if _, ok := r.Value.([]any); !ok {
+			return fmt.Errorf("operator in expects a list, got %v", r.Value)
+		}
+	}
+	return nil
+}
+
+// checkValidationRules reports the validation rules which cannot be evaluated, so that a mistyped
+// rule is reported when the config is loaded rather than silently never matching.
+func (c *Config) checkValidationRules() error {
+	var errs []error
+	for i := range c.Validations {
+		if err := c.Validations[i].check(); err != nil {
+			errs = append(errs, fmt.Errorf("validations[%d]: %w", i, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid validation rules: %w", err)
+	}
+	return nil
+}
+
+// validateRules returns the violations of the validation rules, each prefixed with the field of the rule.
+func (c *Config) validateRules() []error {
+	var errs []error
+	for i := range c.Validations {
+		rule := &c.Validations[i]
+		if err := rule.check(); err != nil {
+			errs = append(errs, fmt.Errorf("validations[%d]: %w", i, err))
+			continue
+		}
+		if err := ruleOperators[rule.Operator](settingAt(c, rule.Field), rule.Value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rule.Field, err))
+		}
+	}
+	return errs
+}
+
+func compareRule(relation string, compare func(a, b float64) bool) func(setting, value any) error {
+	return func(setting, value any) error {
+		actual, ok := ruleNumber(setting)
+		if !ok {
+			return fmt.Errorf("must be a number to be compared, got %v", setting)
+		}
+		limit, _ := ruleNumber(value)
+		if !compare(actual, limit) {
+			return fmt.Errorf("must be %s %v, got %v", relation, value, setting)
+		}
+		return nil
+	}
+}
+
+func rangeRule(setting, value any) error {
+	actual, ok := ruleNumber(setting)
+	if !ok {
+		return fmt.Errorf("must be a number to be compared, got %v", setting)
+	}
+	bounds := value.([]any)
+	minimum, _ := ruleNumber(bounds[0])
+	maximum, _ := ruleNumber(bounds[1])
+	if actual < minimum || actual > maximum {
+		return fmt.Errorf("must be between %v and %v, got %v", bounds[0], bounds[1], setting)
+	}
+	return nil
+}
+
+// ruleNumber returns the numeric value of a setting or of the value of a rule. Durations are
+// compared as nanoseconds, and may be given in rules as strings such as 30s.
+func ruleNumber(value any) (float64, bool) {
+	if s, ok := value.(string); ok {
+		d, err := time.ParseDuration(s)
+		return float64(d), err == nil
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// equalRuleValues reports whether a setting equals the value of a rule, comparing numbers by
+// value whatever their type, and other values by their string form.
+func equalRuleValues(setting, value any) bool {
+	if a, ok := ruleNumber(setting); ok {
+		b, ok := ruleNumber(value)
+		return ok && a == b
+	}
+	return fmt.Sprint(setting) == fmt.Sprint(value)
+}
+
+// isSchemaKey reports whether key is the dotted key of a setting of the Config schema.
+func isSchemaKey(key string) bool {
+	for _, field := range schemaFields() {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationRules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RangeSatisfied", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `otel:
+  sampling-rate: 0.2
+validations:
+  - field: otel.sampling-rate
+    operator: range
+    value: [0.1, 0.5]
+  - field: allow_private_registry_ip
+    operator: eq
+    value: false
+`)
+		require.NoError(t, err)
+		assert.Len(t, config.Validations, 2)
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("RangeViolated", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTestConfig(t, `otel:
+  sampling-rate: 0.8
+validations:
+  - field: otel.sampling-rate
+    operator: range
+    value: [0.1, 0.5]
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "otel.sampling-rate: must be between 0.1 and 0.5, got 0.8")
+	})
+
+	t.Run("UnsupportedOperator", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTestConfig(t, `validations:
+  - field: otel.sampling-rate
+    operator: between
+    value: [0.1, 0.5]
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `validations[0]: unsupported operator "between"`)
+	})
+
+	t.Run("MissingOperator", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTestConfig(t, `validations:
+  - field: otel.sampling-rate
+    value: 0.5
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validations[0]: operator: must not be empty")
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTestConfig(t, `validations:
+  - field: otel.port
+    operator: gte
+    value: 1024
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validations[0]: unknown config key: otel.port")
+	})
+}
+
+func TestValidateRuleOperators(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		RegistryUrl: "https://registry.example.com",
+		OTEL:        OpenTelemetryConfig{SamplingRate: 0.5},
+		Validations: []ValidationRule{
+			{Field: "registry_url", Operator: "eq", Value: "https://registry.example.com"},
+			{Field: "registry_url", Operator: "ne", Value: "https://registry.example.com"},
+			{Field: "otel.sampling-rate", Operator: "gt", Value: 0.5},
+			{Field: "otel.sampling-rate", Operator: "in", Value: []any{0.25, 0.5}},
+			{Field: "otel.sampling-rate", Operator: "match", Value: "0.5"},
+		},
+	}
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "registry_url: must not be https://registry.example.com")
+	assert.Contains(t, err.Error(), "otel.sampling-rate: must be greater than 0.5, got 0.5")
+	assert.Contains(t, err.Error(), `validations[4]: unsupported operator "match"`)
+	assert.NotContains(t, err.Error(), "must be one of")
+	assert.NotContains(t, err.Error(), "registry_url: must be")
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaViolation is a setting violating the JSON Schema a config is validated against by
+// ValidateAgainstSchema.
+type SchemaViolation struct {
+	// Pointer is the JSON pointer of the violating setting, e.g. "/otel/endpoint", or "" for the config itself.
+	Pointer string
+	// Message describes the violation.
+	Message string
+}
+
+func (v *SchemaViolation) Error() string {
+	return fmt.Sprintf("#%s: %s", v.Pointer, v.Message)
+}
+
+// ValidateAgainstSchema validates the effective config against the JSON Schema at schemaPath, which
+// may reference other schemas relative to it. The config is validated as it is written in the config
+// file, with settings named by their yaml keys and durations given as strings such as "30s". Resolved
+// secrets are validated as the references they were resolved from. Every violation is returned as a
+// *SchemaViolation, joined together with errors.Join.
+func (c *Config) ValidateAgainstSchema(schemaPath string) error {
+	schema, err := jsonschema.Compile(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to compile schema %s: %w", schemaPath, err)
+	}
+	doc, err := c.schemaDocument()
+	if err != nil {
+		return err
+	}
+
+	err = schema.Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	var violations []error
+	collectViolations(validationErr, &violations)
+	return errors.Join(violations...)
+}
+
+// schemaDocument returns the effective config as a JSON document.
+func (c *Config) schemaDocument() (any, error) {
+	data, err := yaml.Marshal(c.withSecretReferences())
+	if err != nil {
+		return nil, fmt.Errorf("error serializing config: %w", err)
+	}
+	var values any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("error serializing config: %w", err)
+	}
+	data, err = json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error serializing config: %w", err)
+	}
+	return doc, nil
+}
+
+// collectViolations adds the leaf errors of a validation error to violations, in the order reported.
+func collectViolations(err *jsonschema.ValidationError, violations *[]error) {
+	if len(err.Causes) == 0 {
+		*violations = append(*violations, &SchemaViolation{Pointer: err.InstanceLocation, Message: err.Message})
+		return
+	}
+	for _, cause := range err.Causes {
+		collectViolations(cause, violations)
+	}
+}
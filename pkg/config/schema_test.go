@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+const testConfigSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["registry_url", "otel"],
+  "properties": {
+    "registry_url": {"type": "string", "pattern": "^https://"},
+    "features": {"type": "object", "additionalProperties": {"type": "boolean"}},
+    "otel": {
+      "type": "object",
+      "required": ["endpoint"],
+      "properties": {
+        "sampling-rate": {"type": "number", "maximum": 1}
+      }
+    }
+  }
+}`
+
+func TestValidateAgainstSchema(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(testConfigSchema), 0600))
+
+	t.Run("Conforming", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `registry_url: https://example.com/registry.json
+features:
+  beta: true
+otel:
+  endpoint: collector:4318
+  sampling-rate: 0.5
+`)
+		require.NoError(t, err)
+		assert.NoError(t, config.ValidateAgainstSchema(schemaPath))
+	})
+
+	t.Run("Violating", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `registry_url: http://example.com/registry.json
+otel:
+  sampling-rate: 2
+`)
+		require.NoError(t, err)
+		err = config.ValidateAgainstSchema(schemaPath)
+		require.Error(t, err)
+
+		var violations []SchemaViolation
+		for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var violation *SchemaViolation
+			require.True(t, errors.As(err, &violation))
+			violations = append(violations, *violation)
+		}
+		pointers := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			pointers = append(pointers, violation.Pointer)
+			assert.NotEmpty(t, violation.Message)
+		}
+		assert.ElementsMatch(t, []string{"/registry_url", "/otel", "/otel/sampling-rate"}, pointers)
+		assert.Contains(t, err.Error(), "#/registry_url: ")
+		assert.Contains(t, err.Error(), "#/otel: missing properties: 'endpoint'")
+	})
+
+	t.Run("MissingRequiredSetting", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "registry_url: https://example.com/registry.json\n")
+		require.NoError(t, err)
+
+		err = config.ValidateAgainstSchema(schemaPath)
+		require.Error(t, err)
+		assert.Equal(t, "#: missing properties: 'otel'", err.Error())
+	})
+
+	t.Run("MissingSchema", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "registry_url: https://example.com/registry.json\n")
+		require.NoError(t, err)
+
+		err = config.ValidateAgainstSchema(filepath.Join(t.TempDir(), "missing.json"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to compile schema")
+	})
+}
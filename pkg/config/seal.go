@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+)
+
+// ErrConfigSealed is returned when changing a config sealed by Seal.
+var ErrConfigSealed = errors.New("config is sealed")
+
+// SealOptions configures how a config sealed by Seal refuses changes.
+type SealOptions struct {
+	// Panic makes changes to the sealed config panic with ErrConfigSealed instead of returning it,
+	// so that accidental mutation is caught where it happens, e.g. in tests.
+	Panic bool
+	// AllowReload lets a Watcher whose current config is sealed replace it when reloading, the
+	// reloaded config being sealed with the same options. Reloads are refused otherwise.
+	AllowReload bool
+}
+
+// Seal marks c immutable once it has been initialized: SetValidated, ApplyOverrides, and Set and
+// Commit of the transactions begun on it, refuse to change it from then on, as do Watcher reloads
+// unless opts allow them. Reading the config is unaffected. A config cannot be unsealed.
+func (c *Config) Seal(opts SealOptions) {
+	c.seal = &opts
+}
+
+// Sealed reports whether c has been sealed by Seal.
+func (c *Config) Sealed() bool {
+	return c.seal != nil
+}
+
+// checkSealed returns ErrConfigSealed, or panics with it if so configured, if c is sealed.
+func (c *Config) checkSealed() error {
+	if c.seal == nil {
+		return nil
+	}
+	if c.seal.Panic {
+		panic(ErrConfigSealed)
+	}
+	return ErrConfigSealed
+}
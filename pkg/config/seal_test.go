@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSeal(t *testing.T) {
+	t.Parallel()
+	_, configPath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(configPath, []byte("registry_url: https://registry.example.com/registry.json\n"), 0600))
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	require.NoError(t, err)
+	assert.False(t, config.Sealed())
+
+	config.Seal(SealOptions{})
+	assert.True(t, config.Sealed())
+
+	txn := config.Begin()
+	require.ErrorIs(t, txn.Set("registry_url", "https://other.example.com"), ErrConfigSealed)
+	require.ErrorIs(t, txn.Commit(), ErrConfigSealed)
+	require.ErrorIs(t, config.SetValidated("registry_url", "https://other.example.com"), ErrConfigSealed)
+	require.ErrorIs(t, config.ApplyOverrides([]string{"registry_url=https://other.example.com"}), ErrConfigSealed)
+
+	// Reads still work, and nothing was changed in memory or on disk.
+	assert.Equal(t, "https://registry.example.com/registry.json", config.RegistryUrl)
+	assert.Equal(t, "https://registry.example.com/registry.json", config.CachedString("registry_url"))
+	raw, err := config.GetRaw("registry_url")
+	require.NoError(t, err)
+	assert.Equal(t, "https://registry.example.com/registry.json", raw)
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	var stored map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &stored))
+	assert.Equal(t, "https://registry.example.com/registry.json", stored["registry_url"])
+}
+
+func TestSealPanic(t *testing.T) {
+	t.Parallel()
+	config := &Config{}
+	config.Seal(SealOptions{Panic: true})
+
+	assert.PanicsWithValue(t, ErrConfigSealed, func() {
+		_ = config.ApplyOverrides([]string{"registry_url=https://other.example.com"})
+	})
+	assert.NotPanics(t, func() { _ = config.CachedString("registry_url") })
+}
+
+func TestSealWatcherReload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Blocked", func(t *testing.T) {
+		t.Parallel()
+		w, configPath := newTestWatcher(t, "registry_url: https://one.example.com/registry.json\n")
+		w.Config().Seal(SealOptions{})
+		require.NoError(t, os.WriteFile(configPath, []byte("registry_url: https://two.example.com/registry.json\n"), 0600))
+
+		_, err := w.Reload("test")
+		require.ErrorIs(t, err, ErrConfigSealed)
+		assert.Equal(t, "https://one.example.com/registry.json", w.Config().RegistryUrl)
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		t.Parallel()
+		w, configPath := newTestWatcher(t, "registry_url: https://one.example.com/registry.json\n")
+		w.Config().Seal(SealOptions{AllowReload: true})
+		require.NoError(t, os.WriteFile(configPath, []byte("registry_url: https://two.example.com/registry.json\n"), 0600))
+
+		changed, err := w.Reload("test")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"registry_url"}, changed)
+		assert.Equal(t, "https://two.example.com/registry.json", w.Config().RegistryUrl)
+		assert.True(t, w.Config().Sealed(), "the reloaded config is sealed as well")
+		require.ErrorIs(t, w.Config().Begin().Set("registry_url", "https://three.example.com"), ErrConfigSealed)
+	})
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// SecretReferencePrefix starts settings which reference a secret held by an external secret
+// manager rather than holding the value itself, e.g. "secret://vault/toolhive/otel#token".
+// The host names the provider, whose registered SecretResolver resolves the rest of the reference.
+const SecretReferencePrefix = "secret://"
+
+// SecretReference identifies a secret held by an external secret manager.
+type SecretReference struct {
+	// Provider names the secret manager, e.g. "vault".
+	Provider string
+	// Path locates the secret within the secret manager, e.g. "toolhive/otel".
+	Path string
+	// Field selects a field of the secret, and is empty if the reference has no fragment.
+	Field string
+}
+
+// SecretResolver resolves references to the secrets held by an external secret manager.
+type SecretResolver interface {
+	ResolveSecret(ctx context.Context, ref SecretReference) (string, error)
+}
+
+var (
+	secretResolvers     = map[string]SecretResolver{}
+	secretResolversLock = &sync.RWMutex{}
+)
+
+// RegisterSecretResolver registers the resolver used for references to the named provider,
+// replacing any resolver previously registered for it.
+func RegisterSecretResolver(provider string, resolver SecretResolver) {
+	secretResolversLock.Lock()
+	defer secretResolversLock.Unlock()
+	secretResolvers[provider] = resolver
+}
+
+// ParseSecretReference parses a reference of the form secret://<provider>/<path>#<field>.
+func ParseSecretReference(value string) (SecretReference, error) {
+	if !strings.HasPrefix(value, SecretReferencePrefix) {
+		return SecretReference{}, fmt.Errorf("secret reference must start with %s", SecretReferencePrefix)
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		// The reference itself is not included, as it may reveal where secrets are kept.
+		return SecretReference{}, fmt.Errorf("invalid secret reference: expected %s<provider>/<path>#<field>", SecretReferencePrefix)
+	}
+	return SecretReference{Provider: u.Host, Path: strings.TrimPrefix(u.Path, "/"), Field: u.Fragment}, nil
+}
+
+// resolvedSecret records a setting which held a secret reference before it was resolved.
+type resolvedSecret struct {
+	key       string
+	index     []int
+	mapKey    string
+	reference string
+}
+
+// resolveSecrets replaces the secret references held by string settings, and by the values of
+// string map settings, with the secrets they reference. Errors name the setting, never the reference.
+func (c *Config) resolveSecrets(ctx context.Context) error {
+	value := reflect.ValueOf(c).Elem()
+	for _, field := range schemaFields() {
+		setting := value.FieldByIndex(field.Index)
+		switch {
+		case setting.Kind() == reflect.String:
+			if !strings.HasPrefix(setting.String(), SecretReferencePrefix) {
+				continue
+			}
+			secret, err := resolveSecret(ctx, setting.String())
+			if err != nil {
+				return fmt.Errorf("failed to resolve secret for %s: %w", field.Key, err)
+			}
+			c.secrets = append(c.secrets, resolvedSecret{key: field.Key, index: field.Index, reference: setting.String()})
+			setting.SetString(secret)
+		case setting.Kind() == reflect.Map && setting.Type().Elem().Kind() == reflect.String:
+			for _, mapKey := range setting.MapKeys() {
+				reference := setting.MapIndex(mapKey).String()
+				if !strings.HasPrefix(reference, SecretReferencePrefix) {
+					continue
+				}
+				key := field.Key + "." + mapKey.String()
+				secret, err := resolveSecret(ctx, reference)
+				if err != nil {
+					return fmt.Errorf("failed to resolve secret for %s: %w", key, err)
+				}
+				c.secrets = append(c.secrets, resolvedSecret{
+					key: key, index: field.Index, mapKey: mapKey.String(), reference: reference,
+				})
+				setting.SetMapIndex(mapKey, reflect.ValueOf(secret).Convert(setting.Type().Elem()))
+			}
+		}
+	}
+	return nil
+}
+
+// refreshSecrets resolves the secrets and file references recorded when c was resolved again, replacing
+// their values. Maps are copied before being modified.
+func (c *Config) refreshSecrets(ctx context.Context) error {
+	value := reflect.ValueOf(c).Elem()
+	copied := map[string]bool{}
+	for _, secret := range c.secrets {
+		contents, err := c.resolveRecordedSecret(ctx, secret)
+		if err != nil {
+			return err
+		}
+		setting := value.FieldByIndex(secret.index)
+		if secret.mapKey == "" {
+			setting.SetString(contents)
+			continue
+		}
+		mapName := strings.TrimSuffix(secret.key, "."+secret.mapKey)
+		if !copied[mapName] {
+			setting.Set(cloneMap(setting))
+			copied[mapName] = true
+		}
+		setting.SetMapIndex(reflect.ValueOf(secret.mapKey), reflect.ValueOf(contents).Convert(setting.Type().Elem()))
+	}
+	return nil
+}
+
+// resolveRecordedSecret resolves a secret recorded when c was resolved: a secret reference, a file
+// reference, or a sibling _file key. Errors name the setting, never the reference.
+func (c *Config) resolveRecordedSecret(ctx context.Context, secret resolvedSecret) (string, error) {
+	if strings.HasPrefix(secret.reference, SecretReferencePrefix) {
+		contents, err := resolveSecret(ctx, secret.reference)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve secret for %s: %w", secret.key, err)
+		}
+		return contents, nil
+	}
+	path, ok := strings.CutPrefix(secret.reference, FileReferencePrefix)
+	if !ok {
+		path = c.values.GetString(secret.key + fileKeySuffix)
+	}
+	contents, err := readFileReference(path, c.dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", secret.key, err)
+	}
+	return contents, nil
+}
+
+// withSecretReferences returns a copy of c in which resolved secrets are replaced by their
+// references again, so that secrets are never written to disk. Maps are copied before being modified.
+func (c *Config) withSecretReferences() *Config {
+	if len(c.secrets) == 0 {
+		return c
+	}
+
+	restored := *c
+	value := reflect.ValueOf(&restored).Elem()
+	copied := map[string]bool{}
+	for _, secret := range c.secrets {
+		setting := value.FieldByIndex(secret.index)
+		if secret.mapKey == "" {
+			setting.SetString(secret.reference)
+			continue
+		}
+		mapName := strings.TrimSuffix(secret.key, "."+secret.mapKey)
+		if !copied[mapName] {
+			setting.Set(cloneMap(setting))
+			copied[mapName] = true
+		}
+		setting.SetMapIndex(reflect.ValueOf(secret.mapKey), reflect.ValueOf(secret.reference).Convert(setting.Type().Elem()))
+	}
+	return &restored
+}
+
+// cloneMap returns a shallow copy of the map held by setting.
+func cloneMap(setting reflect.Value) reflect.Value {
+	clone := reflect.MakeMapWithSize(setting.Type(), setting.Len())
+	for _, key := range setting.MapKeys() {
+		clone.SetMapIndex(key, setting.MapIndex(key))
+	}
+	return clone
+}
+
+// holdsResolvedSecret reports whether the setting at the given dotted key holds a resolved secret.
+func (c *Config) holdsResolvedSecret(key string) bool {
+	for _, secret := range c.secrets {
+		if secret.key == key || strings.HasPrefix(secret.key, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveSecret(ctx context.Context, reference string) (string, error) {
+	ref, err := ParseSecretReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	secretResolversLock.RLock()
+	resolver, ok := secretResolvers[ref.Provider]
+	secretResolversLock.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("no secret resolver registered for provider %q", ref.Provider)
+	}
+	return resolver.ResolveSecret(ctx, ref)
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// SetValidated sets the setting at the given dotted key, e.g. "registry_url", or the entry of a
+// map setting, e.g. "features.beta", from its string form, as given on the command line. The string is
+// parsed into the type of the setting: numbers, booleans, and lists given as
+// comma-separated values. The config is validated with the change applied, and only if it is valid is
+// the change written to the config file it was loaded from and applied to c.
+func (c *Config) SetValidated(path string, raw string) error {
+	if err := c.checkSealed(); err != nil {
+		return err
+	}
+	if c.file == "" {
+		return errors.New("config was not loaded from a config file")
+	}
+
+	path = strings.ToLower(path)
+	t, ok := settingType(path)
+	if !ok {
+		return fmt.Errorf("unknown config key: %s", path)
+	}
+	value, err := parseSetting(raw, t)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", path, err)
+	}
+
+	staged := c.clone()
+	if err := setSetting(staged, path, value); err != nil {
+		return err
+	}
+	if err := staged.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	// The change is applied to the config as stored on disk, so that environment overrides
+	// and resolved secrets are never persisted. It cannot fail, having succeeded above.
+	err = UpdateConfigAtPath(c.file, func(stored *Config) {
+		_ = setSetting(stored, path, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	txnLock.Lock()
+	defer txnLock.Unlock()
+	*c = *staged
+	return nil
+}
+
+// ApplyOverrides applies overrides given as key=value pairs, as collected from repeated command-line
+// flags such as --set otel.endpoint=collector:4318, to c without persisting them. Keys and values are those
+// accepted by SetValidated, and a later pair for the same key wins. Nothing is applied unless every
+// pair is well-formed and the config is valid with all of them applied. SourceOf reports the
+// overridden settings as SourceFlag.
+func (c *Config) ApplyOverrides(pairs []string) error {
+	if err := c.checkSealed(); err != nil {
+		return err
+	}
+	staged := c.clone()
+	for _, pair := range pairs {
+		path, raw, ok := strings.Cut(pair, "=")
+		path = strings.ToLower(strings.TrimSpace(path))
+		if !ok || path == "" {
+			return fmt.Errorf("malformed override %q: expected key=value", pair)
+		}
+		t, ok := settingType(path)
+		if !ok {
+			return fmt.Errorf("invalid override %q: unknown config key: %s", pair, path)
+		}
+		value, err := parseSetting(raw, t)
+		if err != nil {
+			return fmt.Errorf("invalid override %q: invalid value for %s: %w", pair, path, err)
+		}
+		if err := setSetting(staged, path, value); err != nil {
+			return fmt.Errorf("invalid override %q: %w", pair, err)
+		}
+		if !slices.Contains(staged.flags, path) {
+			staged.flags = append(staged.flags, path)
+		}
+	}
+	if err := staged.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	txnLock.Lock()
+	defer txnLock.Unlock()
+	*c = *staged
+	return nil
+}
+
+// GetRaw returns the setting at the given dotted key, or the entry of a map setting, in the string
+// form accepted by SetValidated. Resolved secrets are returned as the references they were resolved from.
+func (c *Config) GetRaw(path string) (string, error) {
+	path = strings.ToLower(path)
+	value := settingAt(c.withSecretReferences(), path)
+	if value == nil {
+		return "", fmt.Errorf("unknown config key: %s", path)
+	}
+
+	raw, ok := formatSetting(value)
+	if !ok {
+		return "", fmt.Errorf("%s cannot be represented as a string", path)
+	}
+	return raw, nil
+}
+
+// settingType returns the type of the setting at the given dotted key, or of the entries of the
+// map setting the key names an entry of.
+func settingType(path string) (reflect.Type, bool) {
+	for _, field := range schemaFields() {
+		if path == field.Key {
+			return field.Field.Type, true
+		}
+		t := field.Field.Type
+		if strings.HasPrefix(path, field.Key+".") && t.Kind() == reflect.Map && t.Key().Kind() == reflect.String {
+			return t.Elem(), true
+		}
+	}
+	return nil, false
+}
+
+// formatSetting returns the string form of the value of a setting, as parsed by parseSetting.
+// It reports false for settings which cannot be set from a string, such as sections and maps.
+func formatSetting(value any) (string, bool) {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Kind() == reflect.Slice && envOverridable(v.Type()):
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(items, ","), true
+	case envOverridable(v.Type()):
+		return fmt.Sprint(value), true
+	default:
+		return "", false
+	}
+}
+
+// parseSetting parses the string form of a setting of type t, as environment overrides are parsed.
+func parseSetting(raw string, t reflect.Type) (any, error) {
+	if !envOverridable(t) {
+		return nil, errors.New("cannot be set from a string")
+	}
+
+	result := reflect.New(t)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       settingDecodeHook(),
+		WeaklyTypedInput: true,
+		Result:           result.Interface(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		return nil, err
+	}
+	return result.Elem().Interface(), nil
+}
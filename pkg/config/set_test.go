@@ -0,0 +1,156 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestSetValidated(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+
+		require.NoError(t, config.SetValidated("OTEL.Endpoint", "collector:4318"))
+		assert.Equal(t, "collector:4318", config.OTEL.Endpoint)
+
+		raw, err := config.GetRaw("otel.endpoint")
+		require.NoError(t, err)
+		assert.Equal(t, "collector:4318", raw)
+
+		reloaded, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "collector:4318", reloaded.OTEL.Endpoint)
+	})
+
+	t.Run("InvalidValuesRejected", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		require.NoError(t, config.SetValidated("otel.sampling-rate", "0.5"))
+		before, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+
+		err = config.SetValidated("otel.sampling-rate", "often")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value for otel.sampling-rate")
+
+		err = config.SetValidated("timeouts.read", "soon")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `timeouts.read: invalid duration "soon"`)
+
+		err = config.SetValidated("registry_urls", "https://example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown config key: registry_urls")
+
+		// Nothing is changed by a rejected value.
+		assert.Equal(t, 0.5, config.OTEL.SamplingRate)
+		after, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, string(before), string(after))
+	})
+
+	t.Run("OtherTypes", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+
+		require.NoError(t, config.SetValidated("otel.sampling-rate", "0.25"))
+		require.NoError(t, config.SetValidated("otel.env-vars", "USER,HOME"))
+		require.NoError(t, config.SetValidated("features.beta", "true"))
+		assert.Equal(t, 0.25, config.OTEL.SamplingRate)
+		assert.Equal(t, []string{"USER", "HOME"}, config.OTEL.EnvVars)
+		assert.True(t, config.Features["beta"])
+
+		raw, err := config.GetRaw("otel.env-vars")
+		require.NoError(t, err)
+		assert.Equal(t, "USER,HOME", raw)
+		raw, err = config.GetRaw("features.beta")
+		require.NoError(t, err)
+		assert.Equal(t, "true", raw)
+
+		assert.Error(t, config.SetValidated("servers", "fetch"))
+		_, err = config.GetRaw("servers")
+		assert.Error(t, err)
+	})
+
+	t.Run("NotLoadedFromFile", func(t *testing.T) {
+		t.Parallel()
+		config := &Config{}
+		assert.Error(t, config.SetValidated("otel.endpoint", "collector:4318"))
+	})
+}
+
+func TestApplyOverrides(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	t.Run("Applied", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "")
+		require.NoError(t, err)
+
+		err = config.ApplyOverrides([]string{
+			"otel.endpoint=first-collector:4318",
+			"OTEL.Sampling-Rate=0.25",
+			"clients.registered_clients=vscode,cursor",
+			"features.beta=yes",
+			"otel.endpoint=collector:4318",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "collector:4318", config.OTEL.Endpoint)
+		assert.Equal(t, 0.25, config.OTEL.SamplingRate)
+		assert.Equal(t, []string{"vscode", "cursor"}, config.Clients.RegisteredClients)
+		assert.True(t, config.Features["beta"])
+
+		assert.Equal(t, SourceFlag, config.SourceOf("otel.endpoint"))
+		assert.Equal(t, SourceFlag, config.SourceOf("features.beta"))
+		assert.True(t, config.IsSet("otel.sampling-rate"))
+		assert.Equal(t, SourceDefault, config.SourceOf("registry_url"))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "")
+		require.NoError(t, err)
+
+		for pair, message := range map[string]string{
+			"otel.sampling-rate":       `malformed override "otel.sampling-rate": expected key=value`,
+			"=0.5":                     `malformed override "=0.5": expected key=value`,
+			"otel.sampling-rates=0.5":  `invalid override "otel.sampling-rates=0.5": unknown config key: otel.sampling-rates`,
+			"otel.sampling-rate=often": `invalid override "otel.sampling-rate=often": invalid value for otel.sampling-rate`,
+		} {
+			err := config.ApplyOverrides([]string{"otel.endpoint=collector:4318", pair})
+			require.Error(t, err, pair)
+			assert.Contains(t, err.Error(), message)
+		}
+		assert.Empty(t, config.OTEL.Endpoint)
+		assert.Equal(t, SourceDefault, config.SourceOf("otel.endpoint"))
+	})
+
+	t.Run("InvalidConfigRejected", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "")
+		require.NoError(t, err)
+
+		err = config.ApplyOverrides([]string{"otel.endpoint=collector:4318", "timeouts.read=soon"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `timeouts.read: invalid duration "soon"`)
+
+		// No override is applied when any is rejected.
+		assert.Empty(t, config.OTEL.Endpoint)
+		assert.Empty(t, config.Timeouts)
+		assert.Equal(t, SourceDefault, config.SourceOf("otel.endpoint"))
+	})
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// Sources a setting's effective value can come from, as reported by SourceOf.
+const (
+	// SourceFlag means the setting is overridden by ApplyOverrides, e.g. from a command-line flag.
+	SourceFlag = "flag"
+	// SourceEnv means the setting is overridden by an environment variable.
+	SourceEnv = "env"
+	// SourceFile means the setting is provided by the config file.
+	SourceFile = "file"
+	// SourceDefault means the setting holds its default value.
+	SourceDefault = "default"
+)
+
+// SourceOf returns where the effective value of the setting at the given dotted path comes
+// from: SourceFlag, SourceEnv, SourceFile or SourceDefault. Command-line flags are only reported
+// when commands apply them through ApplyOverrides.
+func (c *Config) SourceOf(path string) string {
+	path = strings.ToLower(path)
+	if slices.Contains(c.flags, path) {
+		return SourceFlag
+	}
+	if c.env != nil && c.env.IsSet(path) {
+		return SourceEnv
+	}
+	if name, ok := strings.CutPrefix(path, "features."); ok {
+		if _, ok := os.LookupEnv(featureEnvVar(name)); ok {
+			return SourceEnv
+		}
+	}
+	if c.values != nil && c.values.IsSet(path) {
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+// LogConfigSources logs the source of every setting of the config at debug level.
+func (c *Config) LogConfigSources() {
+	for _, field := range schemaFields() {
+		logger.Debugw("config setting source", "key", field.Key, "source", c.SourceOf(field.Key))
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSourceOf(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	_, configPath := SetupTestConfig(t, nil)
+	content := `registry_url: https://file.example.com/registry.json
+otel:
+  endpoint: localhost:4318
+features:
+  from-file: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	t.Setenv("TOOLHIVE_REGISTRY_URL", "https://env.example.com/registry.json")
+	t.Setenv("TOOLHIVE_FEATURE_FROM_ENV", "true")
+
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, SourceEnv, config.SourceOf("registry_url"))
+	assert.Equal(t, SourceFile, config.SourceOf("otel.endpoint"))
+	assert.Equal(t, SourceDefault, config.SourceOf("otel.sampling-rate"))
+	assert.Equal(t, SourceDefault, config.SourceOf("local_registry_path"))
+	assert.Equal(t, SourceFile, config.SourceOf("features.from-file"))
+	assert.Equal(t, SourceEnv, config.SourceOf("features.from-env"))
+
+	t.Run("LogConfigSources", func(t *testing.T) { //nolint:paralleltest // Replaces the global logger
+		core, logs := observer.New(zapcore.DebugLevel)
+		defer zap.ReplaceGlobals(zap.New(core))()
+
+		config.LogConfigSources()
+
+		assert.Equal(t, len(schemaFields()), logs.Len())
+		registryURL := logs.FilterField(zap.String("key", "registry_url")).All()
+		require.Len(t, registryURL, 1)
+		assert.Equal(t, zapcore.DebugLevel, registryURL[0].Level)
+		assert.Equal(t, SourceEnv, registryURL[0].ContextMap()["source"])
+	})
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// StrictEnvVar is the environment variable which, when set to true, makes loading a config
+// file containing keys that are not part of the Config schema fail rather than ignoring them.
+const StrictEnvVar = "TOOLHIVE_CONFIG_STRICT"
+
+// checkUnknownKeys reports keys of the config file which are not part of the Config schema.
+// In strict mode they are an error, otherwise they are ignored with a debug log.
+func (c *Config) checkUnknownKeys() error {
+	unknown := c.unknownKeys()
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if strictMode() {
+		return fmt.Errorf("unknown keys in config file: %s", strings.Join(unknown, ", "))
+	}
+	logger.Debugf("ignoring unknown keys in config file: %s", strings.Join(unknown, ", "))
+	return nil
+}
+
+// unknownKeys returns the sorted keys of the config file which are not part of the Config schema.
+func (c *Config) unknownKeys() []string {
+	if c.values == nil {
+		return nil
+	}
+
+	known := map[string]bool{}
+	var mapPrefixes []string
+	for _, field := range schemaFields() {
+		known[field.Key] = true
+		if field.Field.Type.Kind() == reflect.Map {
+			mapPrefixes = append(mapPrefixes, field.Key+".")
+		}
+	}
+
+	for _, key := range knownFileKeys() {
+		known[key] = true
+	}
+
+	var unknown []string
+	for _, key := range c.values.AllKeys() {
+		if known[key] || hasAnyPrefix(key, mapPrefixes) {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// strictMode reports whether strict mode has been enabled through StrictEnvVar.
+func strictMode() bool {
+	strict, err := strconv.ParseBool(os.Getenv(StrictEnvVar))
+	return err == nil && strict
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestStrictMode(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	content := `registry_ulr: https://example.com/registry.json
+otel:
+  endpoint: localhost:4318
+  sampling_rate: 0.5
+features:
+  my-feature: true
+servers:
+  - name: fetch
+    image: ghcr.io/stackloklabs/gofetch/server
+`
+
+	t.Run("Strict", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(StrictEnvVar, "true")
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+		_, err := LoadOrCreateConfigWithPath(configPath)
+		require.Error(t, err)
+		assert.Equal(t, "unknown keys in config file: otel.sampling_rate, registry_ulr", err.Error())
+	})
+
+	t.Run("StrictWithKnownKeys", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(StrictEnvVar, "true")
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte("registry_url: https://example.com/registry.json\n"), 0600))
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/registry.json", config.RegistryUrl)
+	})
+
+	t.Run("Lenient", func(t *testing.T) { //nolint:paralleltest // Replaces the global logger
+		t.Setenv(StrictEnvVar, "")
+		core, logs := observer.New(zapcore.DebugLevel)
+		defer zap.ReplaceGlobals(zap.New(core))()
+
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "", config.RegistryUrl)
+
+		debugLogs := logs.FilterMessageSnippet("unknown keys").FilterLevelExact(zapcore.DebugLevel).All()
+		require.Len(t, debugLogs, 1)
+		assert.Contains(t, debugLogs[0].Message, "registry_ulr")
+	})
+}
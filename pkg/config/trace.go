@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// SourceLayer describes a source of settings considered when loading a config, as returned by LoadWithTrace.
+type SourceLayer struct {
+	// Source is the kind of source: SourceDefault, SourceFile or SourceEnv.
+	Source string `json:"source"`
+	// Location identifies the source: the path of the config file or the environment variables read.
+	Location string `json:"location,omitempty"`
+	// Applied reports whether the source was present and its settings applied.
+	Applied bool `json:"applied"`
+	// Keys are the dotted keys of the settings the source provided, in schema order.
+	Keys []string `json:"keys,omitempty"`
+}
+
+// LoadWithTrace loads the config at configPath as LoadOrCreateConfigWithPath does, and also returns the
+// sources of settings which were considered, in precedence order from lowest to highest: the defaults,
+// the config file, the settings held by ConfigJSONEnvVar and the individual environment overrides.
+// Each later source overrides the settings provided by earlier ones. Sources which were not present,
+// such as a config file which did not exist yet, are included but not applied. Command-line flags are
+// not included, since commands apply them on top of the loaded config with ApplyOverrides.
+func LoadWithTrace(configPath string) (*Config, []SourceLayer, error) {
+	if configPath == "" {
+		var err error
+		configPath, err = getConfigPath()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch config path: %w", err)
+		}
+	}
+	// #nosec G304: The file is the one being loaded.
+	fileData, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("unable to read config file %s: %w", configPath, err)
+	}
+	fileExists := err == nil
+
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layers := []SourceLayer{{Source: SourceDefault, Applied: true}}
+	fileLayer := SourceLayer{Source: SourceFile, Location: configPath, Applied: fileExists}
+	if fileExists {
+		fileLayer.Keys = keysSetIn(fileData)
+	}
+	layers = append(layers, fileLayer)
+
+	jsonLayer := SourceLayer{Source: SourceEnv, Location: ConfigJSONEnvVar}
+	if raw := os.Getenv(ConfigJSONEnvVar); raw != "" {
+		jsonLayer.Applied = true
+		jsonLayer.Keys = keysSetIn([]byte(raw))
+	}
+	layers = append(layers, jsonLayer)
+
+	envLayer := SourceLayer{Source: SourceEnv, Location: currentEnvPrefix() + "_*"}
+	envLayer.Keys = setKeys(config.env)
+	envLayer.Applied = len(envLayer.Keys) > 0
+	layers = append(layers, envLayer)
+
+	return config, layers, nil
+}
+
+// LogSourceLayers logs the sources of settings returned by LoadWithTrace at debug level, in order.
+func LogSourceLayers(layers []SourceLayer) {
+	for _, layer := range layers {
+		logger.Debugw("config source layer",
+			"source", layer.Source, "location", layer.Location, "applied", layer.Applied, "keys", layer.Keys)
+	}
+}
+
+// keysSetIn returns the dotted keys of the settings provided by a config file, or by JSON settings,
+// in schema order. Content which cannot be parsed provides no settings.
+func keysSetIn(data []byte) []string {
+	values, err := readValues(data)
+	if err != nil {
+		return nil
+	}
+	return setKeys(values)
+}
+
+// setKeys returns the dotted keys of the settings set in values, in schema order.
+func setKeys(values *viper.Viper) []string {
+	if values == nil {
+		return nil
+	}
+	var keys []string
+	for _, field := range schemaFields() {
+		if values.IsSet(field.Key) {
+			keys = append(keys, field.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestLoadWithTrace(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+
+	t.Run("AllSources", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte(`registry_url: https://file.example.com/registry.json
+otel:
+  endpoint: file-endpoint:4318
+`), 0600))
+		t.Setenv(ConfigJSONEnvVar, `{"otel": {"sampling-rate": 0.5}}`)
+		t.Setenv("TOOLHIVE_OTEL_ENDPOINT", "env-endpoint:4318")
+
+		config, layers, err := LoadWithTrace(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "env-endpoint:4318", config.OTEL.Endpoint)
+		assert.Equal(t, []SourceLayer{
+			{Source: SourceDefault, Applied: true},
+			{Source: SourceFile, Location: configPath, Applied: true, Keys: []string{"registry_url", "otel.endpoint"}},
+			{Source: SourceEnv, Location: ConfigJSONEnvVar, Applied: true, Keys: []string{"otel.sampling-rate"}},
+			{Source: SourceEnv, Location: "TOOLHIVE_*", Applied: true, Keys: []string{"otel.endpoint"}},
+		}, layers)
+
+		t.Run("LogSourceLayers", func(t *testing.T) { //nolint:paralleltest // Replaces the global logger
+			core, logs := observer.New(zapcore.DebugLevel)
+			defer zap.ReplaceGlobals(zap.New(core))()
+
+			LogSourceLayers(layers)
+
+			entries := logs.FilterMessage("config source layer").All()
+			require.Len(t, entries, 4)
+			assert.Equal(t, ConfigJSONEnvVar, entries[2].ContextMap()["location"])
+		})
+	})
+
+	t.Run("SkippedSources", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoFileExists(t, configPath)
+		t.Setenv(ConfigJSONEnvVar, "")
+
+		_, layers, err := LoadWithTrace(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, []SourceLayer{
+			{Source: SourceDefault, Applied: true},
+			{Source: SourceFile, Location: configPath},
+			{Source: SourceEnv, Location: ConfigJSONEnvVar},
+			{Source: SourceEnv, Location: "TOOLHIVE_*"},
+		}, layers)
+	})
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ConfigTxn stages changes to a Config, which are applied together by Commit or discarded by Rollback.
+//
+//nolint:revive // Intentionally named ConfigTxn despite package name, as Begin on Config returns it
+type ConfigTxn struct {
+	config *Config
+	staged *Config
+	done   bool
+}
+
+// txnLock serializes commits, so that the settings of a config are never replaced concurrently.
+var txnLock = &sync.Mutex{}
+
+// Begin starts a transaction staging changes to c. Nothing is changed until Commit is called.
+func (c *Config) Begin() *ConfigTxn {
+	return &ConfigTxn{config: c, staged: c.clone()}
+}
+
+// Set stages a change of the setting at the given dotted key, e.g. "otel.endpoint", or of an entry
+// of a map setting, e.g. "features.beta", to value. The value must be assignable or convertible to
+// the type of the setting. It is only validated along with the other staged changes by Commit.
+func (t *ConfigTxn) Set(key string, value any) error {
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+	if err := t.config.checkSealed(); err != nil {
+		return err
+	}
+
+	return setSetting(t.staged, key, value)
+}
+
+// Commit validates the config with every staged change applied and, if it is valid, replaces the
+// settings of the config with it in a single step. If validation fails, the config is left unchanged
+// and the transaction remains open, so that the invalid change can be corrected or rolled back.
+// Commits are serialized, but consumers reading the config concurrently should hold a View of it
+// or obtain it through a Watcher instead.
+func (t *ConfigTxn) Commit() error {
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+	if err := t.config.checkSealed(); err != nil {
+		return err
+	}
+	if err := t.staged.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	txnLock.Lock()
+	defer txnLock.Unlock()
+	*t.config = *t.staged
+	t.done = true
+	return nil
+}
+
+// Rollback discards the staged changes and finishes the transaction.
+func (t *ConfigTxn) Rollback() {
+	t.staged = nil
+	t.done = true
+}
+
+// setSetting sets the setting of c at the given dotted key, or the entry of a map setting, to value.
+func setSetting(c *Config, key string, value any) error {
+	key = strings.ToLower(key)
+	settings := reflect.ValueOf(c).Elem()
+	for _, field := range schemaFields() {
+		setting := settings.FieldByIndex(field.Index)
+		if key == field.Key {
+			converted, err := convertSetting(value, setting.Type())
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			setting.Set(converted)
+			return nil
+		}
+		name, ok := strings.CutPrefix(key, field.Key+".")
+		if !ok || setting.Kind() != reflect.Map || setting.Type().Key().Kind() != reflect.String {
+			continue
+		}
+		converted, err := convertSetting(value, setting.Type().Elem())
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if setting.IsNil() {
+			setting.Set(reflect.MakeMap(setting.Type()))
+		}
+		setting.SetMapIndex(reflect.ValueOf(name).Convert(setting.Type().Key()), converted)
+		return nil
+	}
+	return fmt.Errorf("unknown config key: %s", key)
+}
+
+// convertSetting converts value to the type of a setting.
+func convertSetting(value any, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case v.Type().ConvertibleTo(t) && v.Kind() != reflect.String && t.Kind() != reflect.String:
+		return v.Convert(t), nil
+	case v.Kind() == reflect.String && t.Kind() == reflect.String:
+		return v.Convert(t), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot use %T as %s", value, t)
+	}
+}
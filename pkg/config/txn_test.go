@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigTxn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Commit", func(t *testing.T) {
+		t.Parallel()
+		config := &Config{RegistryUrl: "https://one.example.com/registry.json"}
+
+		txn := config.Begin()
+		require.NoError(t, txn.Set("registry_url", "https://two.example.com/registry.json"))
+		require.NoError(t, txn.Set("otel.sampling-rate", 0.5))
+		require.NoError(t, txn.Set("features.beta", true))
+		require.NoError(t, txn.Set("servers", []ServerConfig{{Name: "fetch", Image: "ghcr.io/stackloklabs/fetch:latest"}}))
+
+		// Nothing changes until the transaction is committed.
+		assert.Equal(t, "https://one.example.com/registry.json", config.RegistryUrl)
+		assert.Nil(t, config.Features)
+
+		require.NoError(t, txn.Commit())
+		assert.Equal(t, "https://two.example.com/registry.json", config.RegistryUrl)
+		assert.Equal(t, 0.5, config.OTEL.SamplingRate)
+		assert.Equal(t, map[string]bool{"beta": true}, config.Features)
+		assert.Len(t, config.Servers, 1)
+
+		assert.Error(t, txn.Set("registry_url", "https://three.example.com/registry.json"))
+		assert.Error(t, txn.Commit())
+	})
+
+	t.Run("InvalidCommitChangesNothing", func(t *testing.T) {
+		t.Parallel()
+		config := &Config{RegistryUrl: "https://one.example.com/registry.json"}
+
+		txn := config.Begin()
+		require.NoError(t, txn.Set("registry_url", "https://two.example.com/registry.json"))
+		require.NoError(t, txn.Set("servers", []ServerConfig{{Name: "fetch"}}))
+
+		err := txn.Commit()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "servers[0].image: must not be empty")
+		assert.Equal(t, &Config{RegistryUrl: "https://one.example.com/registry.json"}, config)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		t.Parallel()
+		config := &Config{Features: map[string]bool{"beta": false}}
+
+		txn := config.Begin()
+		require.NoError(t, txn.Set("features.beta", true))
+		require.NoError(t, txn.Set("allow_private_registry_ip", true))
+		txn.Rollback()
+
+		assert.Equal(t, &Config{Features: map[string]bool{"beta": false}}, config)
+		assert.Error(t, txn.Commit())
+	})
+
+	t.Run("InvalidSet", func(t *testing.T) {
+		t.Parallel()
+		txn := (&Config{}).Begin()
+
+		assert.ErrorContains(t, txn.Set("registry_urls", "https://registry.example.com"), "unknown config key")
+		assert.ErrorContains(t, txn.Set("allow_private_registry_ip", "yes"), "cannot use string as bool")
+		assert.ErrorContains(t, txn.Set("registry_url", 42), "cannot use int as string")
+	})
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// uniqueTag names the field which identifies the entries of a list setting, and so must not be
+// shared by two of them, e.g. unique:"name".
+const uniqueTag = "unique"
+
+// validateUnique returns the values shared by several entries of list settings for the field named
+// by their unique tag, each error naming the setting, the field, the value and the indices of the
+// entries sharing it. Empty values are not compared, as required fields report them.
+func (c *Config) validateUnique() []error {
+	var errs []error
+	value := reflect.ValueOf(c).Elem()
+	for _, field := range schemaFields() {
+		name := field.Field.Tag.Get(uniqueTag)
+		if name == "" {
+			continue
+		}
+		entries := value.FieldByIndex(field.Index)
+		if entries.Kind() != reflect.Slice || entries.Type().Elem().Kind() != reflect.Struct {
+			errs = append(errs, fmt.Errorf("%s: unique applies to lists of sections only", field.Key))
+			continue
+		}
+		index, ok := entryFieldIndex(entries.Type().Elem(), name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unique names unknown field %s", field.Key, name))
+			continue
+		}
+
+		indices := map[string][]int{}
+		var values []string
+		for i := 0; i < entries.Len(); i++ {
+			id := fmt.Sprint(entries.Index(i).Field(index).Interface())
+			if id == "" {
+				continue
+			}
+			if _, seen := indices[id]; !seen {
+				values = append(values, id)
+			}
+			indices[id] = append(indices[id], i)
+		}
+		for _, id := range values {
+			if len(indices[id]) > 1 {
+				errs = append(errs, fmt.Errorf("%s: duplicate %s %q at indices %s", field.Key, name, id, joinIndices(indices[id])))
+			}
+		}
+	}
+	return errs
+}
+
+// entryFieldIndex returns the index of the field of the struct t serialized under the given name.
+func entryFieldIndex(t reflect.Type, name string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if yamlName(t.Field(i)) == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func joinIndices(indices []int) string {
+	items := make([]string, len(indices))
+	for i, index := range indices {
+		items[i] = fmt.Sprint(index)
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniqueServerNames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DuplicateNames", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTestConfig(t, `servers:
+  - name: fetch
+    image: ghcr.io/stackloklabs/gofetch/server
+  - name: github
+    image: ghcr.io/github/github-mcp-server
+  - name: fetch
+    image: ghcr.io/stackloklabs/gofetch/server:latest
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `servers: duplicate name "fetch" at indices 0, 2`)
+		assert.NotContains(t, err.Error(), `"github"`)
+	})
+
+	t.Run("UniqueNames", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, `servers:
+  - name: fetch
+    image: ghcr.io/stackloklabs/gofetch/server
+  - name: github
+    image: ghcr.io/github/github-mcp-server
+`)
+		require.NoError(t, err)
+		assert.Len(t, config.Servers, 2)
+	})
+}
+
+func TestValidateUniqueEmptyValues(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{Servers: []ServerConfig{{Image: "example/a"}, {Image: "example/b"}}}
+	err := config.Validate()
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "duplicate", "empty names are reported as missing rather than duplicated")
+}
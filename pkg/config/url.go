@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stacklok/toolhive/pkg/networking"
+)
+
+// loadFromURLTimeout bounds the time LoadFromURL waits for the config to be fetched.
+var loadFromURLTimeout = 30 * time.Second
+
+// URLOption configures the client LoadFromURL fetches the config with.
+type URLOption func(*networking.HttpClientBuilder)
+
+// WithURLCABundle verifies the certificate of the server serving the config against the CA
+// certificates in the PEM bundle at path instead of the system ones.
+func WithURLCABundle(path string) URLOption {
+	return func(b *networking.HttpClientBuilder) {
+		b.WithCABundle(path)
+	}
+}
+
+// WithURLPrivateIPs allows fetching the config from private IP addresses, which are refused by default.
+func WithURLPrivateIPs(allow bool) URLOption {
+	return func(b *networking.HttpClientBuilder) {
+		b.WithPrivateIPs(allow)
+	}
+}
+
+// LoadFromURL fetches a config document over HTTPS and builds a config from it, without touching
+// the disk. As for other outgoing requests, plain HTTP is only used when INSECURE_DISABLE_URL_VALIDATION
+// is set to true. The document is parsed as JSON if it is served as application/json, or if it is
+// served without a recognized YAML or JSON content type and the path of the URL ends in .json, and as
+// YAML otherwise. The config is resolved as if it had been loaded from a file: documents of a newer
+// schema version are refused and older ones are migrated, environment overrides apply and the result
+// is validated. Relative paths are left as they are.
+// Fetching is given up after 30 seconds.
+func LoadFromURL(rawURL string, opts ...URLOption) (*Config, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config URL %s: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid config URL %s: scheme must be http or https", rawURL)
+	}
+
+	data, contentType, err := fetchConfig(rawURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	if isJSONConfig(contentType, u.Path) {
+		var values map[string]any
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse config json from %s: %w", rawURL, err)
+		}
+		if data, err = yaml.Marshal(values); err != nil {
+			return nil, fmt.Errorf("failed to parse config json from %s: %w", rawURL, err)
+		}
+	}
+
+	var config Config
+	if err := config.decode(data); err != nil {
+		return nil, fmt.Errorf("invalid config from %s: %w", rawURL, err)
+	}
+	if err := config.checkSchemaVersion(rawURL); err != nil {
+		return nil, err
+	}
+	config.migrateSchemaInMemory()
+	if err := config.resolve(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// fetchConfig returns the body and the content type of the document served at rawURL.
+func fetchConfig(rawURL string, opts []URLOption) ([]byte, string, error) {
+	builder := networking.NewHttpClientBuilder().WithTimeout(loadFromURLTimeout)
+	for _, opt := range opts {
+		opt(builder)
+	}
+	client, err := builder.Build()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), loadFromURLTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid config URL %s: %w", rawURL, err)
+	}
+	req.Header.Set("Accept", "application/yaml, application/json;q=0.9, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, "", fmt.Errorf("timed out after %s fetching config from %s", loadFromURLTimeout, rawURL)
+		}
+		return nil, "", fmt.Errorf("failed to fetch config from %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch config from %s: unexpected status %s", rawURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, "", fmt.Errorf("timed out after %s fetching config from %s", loadFromURLTimeout, rawURL)
+		}
+		return nil, "", fmt.Errorf("failed to read config from %s: %w", rawURL, err)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// isJSONConfig reports whether a config document with the given content type, served at urlPath,
+// is JSON rather than YAML.
+func isJSONConfig(contentType, urlPath string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return true
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return false
+	default:
+		return path.Ext(urlPath) == ".json"
+	}
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFromURL(t *testing.T) {
+	t.Parallel()
+
+	documents := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"/config":      {"application/yaml", "registry_url: https://registry.example.com/registry.json\notel:\n  endpoint: localhost:4318\n"},
+		"/config.json": {"text/plain", `{"registry_url": "https://registry.example.com/registry.json", "otel": {"endpoint": "localhost:4318"}}`},
+		"/typed":       {"application/json; charset=utf-8", `{"registry_url": "https://registry.example.com/registry.json"}`},
+		"/invalid":     {"application/yaml", "servers:\n  - name: fetch\n"},
+		"/older":       {"application/yaml", "schema_version: 0\nsecrets:\n  provider_type: basic\n"},
+		"/newer":       {"application/yaml", "schema_version: 1000\n"},
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, ok := documents[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", document.contentType)
+		_, _ = w.Write([]byte(document.body))
+	}))
+	t.Cleanup(server.Close)
+	caBundle := writeCABundle(t, server)
+	opts := []URLOption{WithURLCABundle(caBundle), WithURLPrivateIPs(true)}
+
+	for _, path := range []string{"/config", "/config.json"} {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			config, err := LoadFromURL(server.URL+path, opts...)
+			require.NoError(t, err)
+			assert.Equal(t, "https://registry.example.com/registry.json", config.RegistryUrl)
+			assert.Equal(t, "localhost:4318", config.OTEL.Endpoint)
+			assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion)
+		})
+	}
+
+	t.Run("JSONContentType", func(t *testing.T) {
+		t.Parallel()
+		config, err := LoadFromURL(server.URL+"/typed", opts...)
+		require.NoError(t, err)
+		assert.Equal(t, "https://registry.example.com/registry.json", config.RegistryUrl)
+	})
+
+	t.Run("OlderSchemaVersion", func(t *testing.T) {
+		t.Parallel()
+		config, err := LoadFromURL(server.URL+"/older", opts...)
+		require.NoError(t, err)
+		assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion)
+		assert.Equal(t, "encrypted", config.Secrets.ProviderType)
+		assert.True(t, config.Secrets.SetupCompleted)
+	})
+
+	t.Run("NewerSchemaVersion", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL(server.URL+"/newer", opts...)
+		require.ErrorIs(t, err, ErrConfigTooNew)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL(server.URL+"/missing.yaml", opts...)
+		require.Error(t, err)
+		assert.EqualError(t, err, "failed to fetch config from "+server.URL+"/missing.yaml: unexpected status 404 Not Found")
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL(server.URL+"/invalid", opts...)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "servers[0].image: must not be empty")
+	})
+
+	t.Run("UntrustedCertificate", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL(server.URL+"/config", WithURLPrivateIPs(true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("PrivateIP", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL(server.URL+"/config", WithURLCABundle(caBundle))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "private IP address")
+	})
+
+	t.Run("PlainHTTP", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL("http://registry.example.com/config.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not HTTPS scheme")
+	})
+
+	t.Run("UnsupportedScheme", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadFromURL("file:///etc/toolhive/config.yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scheme must be http or https")
+	})
+}
+
+// writeCABundle writes the certificate of server to a PEM bundle and returns its path.
+func writeCABundle(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, certificate, 0600))
+	return path
+}
+
+func TestLoadFromURLTimeout(t *testing.T) { //nolint:paralleltest // Replaces the load timeout
+	original := loadFromURLTimeout
+	loadFromURLTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { loadFromURLTimeout = original })
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := LoadFromURL(server.URL+"/config.yaml", WithURLCABundle(writeCABundle(t, server)), WithURLPrivateIPs(true))
+	require.Error(t, err)
+	assert.EqualError(t, err, "timed out after 50ms fetching config from "+server.URL+"/config.yaml")
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/stacklok/toolhive/pkg/transport/types"
+)
+
+// requiredTag marks settings which must not be empty, e.g. required:"true".
+const requiredTag = "required"
+
+// ServerConfig contains the settings of an MCP server declared in the config file.
+// Settings tagged required must not be empty.
+type ServerConfig struct {
+	Name      string   `yaml:"name" required:"true"`
+	Image     string   `yaml:"image" required:"true"`
+	Transport string   `yaml:"transport,omitempty"`
+	Args      []string `yaml:"args,omitempty"`
+}
+
+// Validator checks a config for problems which the config package cannot know about,
+// such as whether a referenced path exists or a port is free. Validators must be
+// deterministic and must not modify the config.
+type Validator func(*Config) error
+
+var (
+	validators     []Validator
+	validatorsLock = &sync.RWMutex{}
+)
+
+// RegisterValidator registers a validator to be run by Config.Validate.
+// Validators run in registration order, after the built-in checks.
+func RegisterValidator(validator Validator) {
+	validatorsLock.Lock()
+	defer validatorsLock.Unlock()
+	validators = append(validators, validator)
+}
+
+// Validate checks the config for invalid settings, including entries of list settings leaving a field
+// tagged required empty or sharing the field named by their unique tag, and numeric settings out of
+// the range set by the settings named in their max and min tags, and for violations of the rules declared in its validations section,
+// then runs the registered validators.
+// All problems found are returned together, each prefixed with the path of the offending field.
+func (c *Config) Validate() error {
+	var errs []error
+	for i := range c.Servers {
+		for _, err := range c.Servers[i].validate() {
+			errs = append(errs, fmt.Errorf("servers[%d].%w", i, err))
+		}
+	}
+	errs = append(errs, c.validateUnique()...)
+	errs = append(errs, c.validateRelations()...)
+	errs = append(errs, c.validateElements()...)
+	errs = append(errs, c.validateRules()...)
+
+	validatorsLock.RLock()
+	defer validatorsLock.RUnlock()
+	for _, validator := range validators {
+		if err := validator(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate returns the problems found in the server settings, each prefixed with the field name.
+func (s *ServerConfig) validate() []error {
+	errs := requiredErrors(reflect.ValueOf(s).Elem(), "")
+	if s.Transport != "" {
+		if _, err := types.ParseTransportType(s.Transport); err != nil {
+			errs = append(errs, fmt.Errorf("transport: %w: %s", err, s.Transport))
+		}
+	}
+	return errs
+}
+
+// requiredErrors returns the fields of the struct v and of its nested sections which are tagged
+// required but left empty, each named by its dotted key.
+func requiredErrors(v reflect.Value, prefix string) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := yamlName(field)
+		if name == "" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			errs = append(errs, requiredErrors(v.Field(i), prefix+name+".")...)
+			continue
+		}
+		if field.Tag.Get(requiredTag) == "true" && v.Field(i).IsZero() {
+			errs = append(errs, fmt.Errorf("%s%s: must not be empty", prefix, name))
+		}
+	}
+	return errs
+}
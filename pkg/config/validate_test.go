@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestLoadServers(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	t.Run("ValidServers", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		content := `servers:
+  - name: fetch
+    image: ghcr.io/stackloklabs/gofetch/server
+    transport: streamable-http
+  - name: github
+    image: ghcr.io/github/github-mcp-server
+    args: ["--read-only"]
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+		config, err := LoadOrCreateConfigWithPath(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, []ServerConfig{
+			{Name: "fetch", Image: "ghcr.io/stackloklabs/gofetch/server", Transport: "streamable-http"},
+			{Name: "github", Image: "ghcr.io/github/github-mcp-server", Args: []string{"--read-only"}},
+		}, config.Servers)
+	})
+
+	t.Run("InvalidServer", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		content := `servers:
+  - name: fetch
+    image: ghcr.io/stackloklabs/gofetch/server
+  - name: broken
+    transport: stdio
+  - name: github
+    image: ghcr.io/github/github-mcp-server
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+		_, err := LoadOrCreateConfigWithPath(configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "servers[1].image")
+		assert.NotContains(t, err.Error(), "servers[0]")
+		assert.NotContains(t, err.Error(), "servers[2]")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Servers: []ServerConfig{
+			{Name: "valid", Image: "example/valid"},
+			{Image: "example/unnamed", Transport: "carrier-pigeon"},
+		},
+	}
+
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "servers[1].name: must not be empty")
+	assert.Contains(t, err.Error(), "servers[1].transport: unsupported transport type: carrier-pigeon")
+
+	assert.NoError(t, (&Config{}).Validate())
+}
+
+func TestRequiredErrors(t *testing.T) {
+	t.Parallel()
+
+	type section struct {
+		Name string `yaml:"name" required:"true"`
+	}
+	type settings struct {
+		Image   string   `yaml:"image" required:"true"`
+		Args    []string `yaml:"args" required:"true"`
+		Port    int      `yaml:"port"`
+		Section section  `yaml:"section"`
+	}
+
+	errs := requiredErrors(reflect.ValueOf(settings{Args: []string{"--verbose"}}), "")
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "image: must not be empty")
+	assert.EqualError(t, errs[1], "section.name: must not be empty")
+
+	assert.Empty(t, requiredErrors(reflect.ValueOf(settings{Image: "example/image", Args: []string{"--verbose"},
+		Section: section{Name: "fetch"}}), ""))
+}
+
+func TestRegisterValidator(t *testing.T) {
+	t.Parallel()
+
+	// Validators are global, so they only act on configs using the test registry.
+	const testRegistry = "https://validators.example.com/registry.json"
+	var calls []string
+	RegisterValidator(func(c *Config) error {
+		if c.RegistryUrl == testRegistry {
+			calls = append(calls, "passing")
+		}
+		return nil
+	})
+	RegisterValidator(func(c *Config) error {
+		if c.RegistryUrl != testRegistry {
+			return nil
+		}
+		calls = append(calls, "failing")
+		return errors.New("registry is not reachable")
+	})
+
+	config := &Config{
+		RegistryUrl: testRegistry,
+		Servers:     []ServerConfig{{Name: "unnamed-image"}},
+	}
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Equal(t, "servers[0].image: must not be empty\nregistry is not reachable", err.Error())
+	assert.Equal(t, []string{"passing", "failing"}, calls, "validators run in registration order")
+}
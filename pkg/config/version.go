@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+)
+
+// CurrentSchemaVersion is the version of the config file schema written by this version of ToolHive.
+// It is incremented whenever a change to the schema would be misread by older versions.
+// Config files without a schema_version predate versioning, and are treated as version 0.
+const CurrentSchemaVersion = 1
+
+// ErrConfigTooNew is returned when loading a config file written for a newer schema version than
+// CurrentSchemaVersion, which this version of ToolHive could silently mangle.
+var ErrConfigTooNew = errors.New("config too new")
+
+// checkSchemaVersion refuses configs of a newer schema version than CurrentSchemaVersion.
+func (c *Config) checkSchemaVersion(configPath string) error {
+	if c.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("%w: %s has schema version %d, but this version of ToolHive supports up to version %d",
+			ErrConfigTooNew, configPath, c.SchemaVersion, CurrentSchemaVersion)
+	}
+	return nil
+}
+
+// migrateSchema upgrades a config loaded from the config file of an older schema version to
+// CurrentSchemaVersion. Backward compatibility fixes are saved as soon as they are applied, while
+// the new schema version is only persisted when the config is next saved.
+func (c *Config) migrateSchema() error {
+	if err := applyBackwardCompatibility(c); err != nil {
+		return fmt.Errorf("failed to apply backward compatibility fixes: %w", err)
+	}
+	c.SchemaVersion = CurrentSchemaVersion
+	return nil
+}
+
+// migrateSchemaInMemory upgrades a config which was not loaded from the config file, such as one
+// built from a map, to CurrentSchemaVersion. Unlike migrateSchema, nothing is saved and no state
+// left behind by older versions is removed.
+func (c *Config) migrateSchemaInMemory() {
+	for _, fix := range compatibilityFixes {
+		fix.apply(c)
+	}
+	c.SchemaVersion = CurrentSchemaVersion
+}
+
+// migration is a change made to a config written by an older version of ToolHive.
+type migration struct {
+	description string
+	// apply changes the config, reporting whether it needed the change.
+	apply func(*Config) bool
+	// cleanup, if set, removes state left behind by older versions once apply has changed a config
+	// being loaded. It is not run by MigrateDryRun.
+	cleanup func()
+}
+
+// MigrationStep describes a migration which upgrading a config file to CurrentSchemaVersion would
+// apply, along with the settings it would change. Sensitive values are redacted.
+type MigrationStep struct {
+	Description string   `json:"description"`
+	Changes     []Change `json:"changes"`
+}
+
+// MigrateDryRun returns the migrations which loading the config file at configPath would apply to
+// upgrade it to CurrentSchemaVersion, in the order they would be applied, without changing the file
+// or removing any state left behind by older versions. It returns no steps for a file already at
+// CurrentSchemaVersion, and ErrConfigTooNew for a file of a newer schema version.
+func MigrateDryRun(configPath string) ([]MigrationStep, error) {
+	configPath = path.Clean(configPath)
+	// #nosec G304: The config file is provided by the caller.
+	configFile, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %s: %w", configPath, err)
+	}
+	var config Config
+	if err := config.decode(configFile); err != nil {
+		return nil, err
+	}
+	if err := config.checkSchemaVersion(configPath); err != nil {
+		return nil, err
+	}
+
+	migrations := append(compatibilityFixes[:len(compatibilityFixes):len(compatibilityFixes)], migration{
+		description: fmt.Sprintf("set the schema version to %d", CurrentSchemaVersion),
+		apply: func(c *Config) bool {
+			if c.SchemaVersion == CurrentSchemaVersion {
+				return false
+			}
+			c.SchemaVersion = CurrentSchemaVersion
+			return true
+		},
+	})
+
+	var steps []MigrationStep
+	for _, m := range migrations {
+		before := config.clone()
+		if !m.apply(&config) {
+			continue
+		}
+		steps = append(steps, MigrationStep{
+			Description: m.description,
+			Changes:     redactedChanges(before, &config, Diff(before, &config)),
+		})
+	}
+	return steps, nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	t.Parallel()
+	logger.Initialize()
+
+	t.Run("FutureVersionRefused", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		content := "schema_version: 2\nregistry_url: https://example.com/registry.json\n"
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+		_, err := LoadOrCreateConfigWithPath(configPath)
+		require.ErrorIs(t, err, ErrConfigTooNew)
+		assert.EqualError(t, err, "config too new: "+configPath+
+			" has schema version 2, but this version of ToolHive supports up to version 1")
+	})
+
+	t.Run("CurrentVersionAccepted", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "schema_version: 1\nregistry_url: https://example.com/registry.json\n")
+		require.NoError(t, err)
+		assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion)
+		assert.Equal(t, "https://example.com/registry.json", config.RegistryUrl)
+	})
+
+	t.Run("OlderVersionMigrated", func(t *testing.T) {
+		t.Parallel()
+		config, err := loadTestConfig(t, "secrets:\n  provider_type: encrypted\n")
+		require.NoError(t, err)
+		assert.Equal(t, CurrentSchemaVersion, config.SchemaVersion)
+		assert.True(t, config.Secrets.SetupCompleted, "backward compatibility fixes are applied")
+	})
+}
+
+func TestSchemaVersionFromConfigJSON(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logger.Initialize()
+	_, configPath := SetupTestConfig(t, nil)
+	require.NoError(t, os.WriteFile(configPath, []byte("schema_version: 1\n"), 0600))
+	t.Setenv(ConfigJSONEnvVar, `{"schema_version": 3}`)
+
+	_, err := LoadOrCreateConfigWithPath(configPath)
+	require.ErrorIs(t, err, ErrConfigTooNew)
+	assert.Contains(t, err.Error(), "TOOLHIVE_CONFIG_JSON has schema version 3")
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	t.Parallel()
+
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		_, configPath := SetupTestConfig(t, nil)
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+		return configPath
+	}
+
+	t.Run("OlderVersion", func(t *testing.T) {
+		t.Parallel()
+		content := "secrets:\n  provider_type: basic\nregistry_url: https://example.com/registry.json\n"
+		configPath := write(t, content)
+
+		steps, err := MigrateDryRun(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, []MigrationStep{
+			{
+				Description: "replace the removed basic secrets provider with the encrypted provider",
+				Changes:     []Change{{Key: "secrets.provider_type", Old: "basic", New: "encrypted"}},
+			},
+			{
+				Description: "mark the configured secrets provider as set up",
+				Changes:     []Change{{Key: "secrets.setup_completed", Old: false, New: true}},
+			},
+			{
+				Description: "set the schema version to 1",
+				Changes:     []Change{{Key: "schema_version", Old: 0, New: 1}},
+			},
+		}, steps)
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data), "the config file is not modified")
+	})
+
+	t.Run("CurrentVersion", func(t *testing.T) {
+		t.Parallel()
+		steps, err := MigrateDryRun(write(t, "schema_version: 1\nsecrets:\n  provider_type: encrypted\n  setup_completed: true\n"))
+		require.NoError(t, err)
+		assert.Empty(t, steps)
+	})
+
+	t.Run("FutureVersion", func(t *testing.T) {
+		t.Parallel()
+		_, err := MigrateDryRun(write(t, "schema_version: 2\n"))
+		require.ErrorIs(t, err, ErrConfigTooNew)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		t.Parallel()
+		_, configPath := SetupTestConfig(t, nil)
+		_, err := MigrateDryRun(configPath)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+// ConfigView is a read-only snapshot of a Config, handed to subsystems which must not modify
+// the shared config. Values returned by a view are copies, so modifying them affects neither
+// the view nor the config it was taken from.
+//
+//nolint:revive // Intentionally named ConfigView despite package name, as View is the method returning it
+type ConfigView interface {
+	// SecretsProviderType returns the type of the secrets provider, e.g. "encrypted".
+	SecretsProviderType() string
+	// SecretsSetupCompleted reports whether the secrets provider has been set up.
+	SecretsSetupCompleted() bool
+	// RegisteredClients returns the names of the clients registered with ToolHive.
+	RegisteredClients() []string
+	// RegistryURL returns the URL of the remote registry, or an empty string if none is configured.
+	RegistryURL() string
+	// LocalRegistryPath returns the path of the local registry file, or an empty string if none is configured.
+	LocalRegistryPath() string
+	// AllowPrivateRegistryIP reports whether the remote registry may be served from a private IP address.
+	AllowPrivateRegistryIP() bool
+	// CACertificatePath returns the path of the CA certificate used for container builds.
+	CACertificatePath() string
+	// OTEL returns the OpenTelemetry settings.
+	OTEL() OpenTelemetryConfig
+	// DefaultGroupMigration reports whether servers have been migrated to the default group.
+	DefaultGroupMigration() bool
+	// FeatureEnabled reports whether the named feature flag is enabled, as Config.FeatureEnabled does.
+	FeatureEnabled(name string) bool
+	// Servers returns the MCP servers declared in the config file.
+	Servers() []ServerConfig
+}
+
+// View returns a read-only snapshot of the config. Later changes to the config are not
+// reflected in the view.
+func (c *Config) View() ConfigView {
+	return &configView{config: c.clone()}
+}
+
+// configView implements ConfigView over a private copy of a Config.
+type configView struct {
+	config *Config
+}
+
+func (v *configView) SecretsProviderType() string {
+	return v.config.Secrets.ProviderType
+}
+
+func (v *configView) SecretsSetupCompleted() bool {
+	return v.config.Secrets.SetupCompleted
+}
+
+func (v *configView) RegisteredClients() []string {
+	return slices.Clone(v.config.Clients.RegisteredClients)
+}
+
+func (v *configView) RegistryURL() string {
+	return v.config.RegistryUrl
+}
+
+func (v *configView) LocalRegistryPath() string {
+	return v.config.LocalRegistryPath
+}
+
+func (v *configView) AllowPrivateRegistryIP() bool {
+	return v.config.AllowPrivateRegistryIp
+}
+
+func (v *configView) CACertificatePath() string {
+	return v.config.CACertificatePath
+}
+
+func (v *configView) OTEL() OpenTelemetryConfig {
+	return v.config.clone().OTEL
+}
+
+func (v *configView) DefaultGroupMigration() bool {
+	return v.config.DefaultGroupMigration
+}
+
+func (v *configView) FeatureEnabled(name string) bool {
+	return v.config.FeatureEnabled(name)
+}
+
+func (v *configView) Servers() []ServerConfig {
+	return v.config.clone().Servers
+}
+
+// clone returns a deep copy of the settings of c. The raw file and environment values, which
+// are never modified once loaded, are shared.
+func (c *Config) clone() *Config {
+	clone := *c
+	clone.Clients.RegisteredClients = slices.Clone(c.Clients.RegisteredClients)
+	clone.OTEL.EnvVars = slices.Clone(c.OTEL.EnvVars)
+	clone.Features = maps.Clone(c.Features)
+	clone.Servers = slices.Clone(c.Servers)
+	for i := range clone.Servers {
+		clone.Servers[i].Args = slices.Clone(c.Servers[i].Args)
+	}
+	clone.Timeouts = maps.Clone(c.Timeouts)
+	clone.Validations = slices.Clone(c.Validations)
+	clone.secrets = slices.Clone(c.secrets)
+	clone.flags = slices.Clone(c.flags)
+	clone.cache = &settingCache{}
+	return &clone
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newViewTestConfig() *Config {
+	return &Config{
+		Secrets:           Secrets{ProviderType: "encrypted", SetupCompleted: true},
+		Clients:           Clients{RegisteredClients: []string{"vscode", "cursor"}},
+		RegistryUrl:       "https://registry.example.com/registry.json",
+		LocalRegistryPath: "/etc/toolhive/registry.json",
+		OTEL: OpenTelemetryConfig{
+			Endpoint: "otel.example.com:4318",
+			EnvVars:  []string{"USER"},
+		},
+		Servers: []ServerConfig{{Name: "fetch", Image: "ghcr.io/stackloklabs/fetch:latest", Args: []string{"--verbose"}}},
+	}
+}
+
+func TestConfigView(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ExposesValues", func(t *testing.T) {
+		t.Parallel()
+		view := newViewTestConfig().View()
+
+		assert.Equal(t, "encrypted", view.SecretsProviderType())
+		assert.True(t, view.SecretsSetupCompleted())
+		assert.Equal(t, []string{"vscode", "cursor"}, view.RegisteredClients())
+		assert.Equal(t, "https://registry.example.com/registry.json", view.RegistryURL())
+		assert.Equal(t, "/etc/toolhive/registry.json", view.LocalRegistryPath())
+		assert.False(t, view.AllowPrivateRegistryIP())
+		assert.Equal(t, "otel.example.com:4318", view.OTEL().Endpoint)
+		assert.Equal(t, []string{"USER"}, view.OTEL().EnvVars)
+		assert.Equal(t, "fetch", view.Servers()[0].Name)
+	})
+
+	t.Run("IsSnapshot", func(t *testing.T) {
+		t.Parallel()
+		config := newViewTestConfig()
+		view := config.View()
+
+		config.RegistryUrl = "https://other.example.com/registry.json"
+		config.Clients.RegisteredClients[0] = "claude-code"
+		config.OTEL.EnvVars[0] = "HOME"
+		config.Servers[0].Args[0] = "--quiet"
+
+		assert.Equal(t, "https://registry.example.com/registry.json", view.RegistryURL())
+		assert.Equal(t, []string{"vscode", "cursor"}, view.RegisteredClients())
+		assert.Equal(t, []string{"USER"}, view.OTEL().EnvVars)
+		assert.Equal(t, []string{"--verbose"}, view.Servers()[0].Args)
+	})
+
+	t.Run("ReturnedValuesAreCopies", func(t *testing.T) {
+		t.Parallel()
+		config := newViewTestConfig()
+		view := config.View()
+
+		view.RegisteredClients()[0] = "claude-code"
+		view.OTEL().EnvVars[0] = "HOME"
+		view.Servers()[0].Args[0] = "--quiet"
+
+		expected := newViewTestConfig()
+		assert.Equal(t, expected.View(), view)
+		assert.Equal(t, expected.Clients, config.Clients)
+		assert.Equal(t, expected.OTEL, config.OTEL)
+		assert.Equal(t, expected.Servers, config.Servers)
+	})
+
+	t.Run("HasNoSetters", func(t *testing.T) {
+		t.Parallel()
+		viewType := reflect.TypeOf((*ConfigView)(nil)).Elem()
+		for i := range viewType.NumMethod() {
+			method := viewType.Method(i)
+			assert.False(t, strings.HasPrefix(method.Name, "Set"), "%s looks like a setter", method.Name)
+			assert.Equal(t, 1, method.Type.NumOut(), "%s must only return a value", method.Name)
+		}
+	})
+}
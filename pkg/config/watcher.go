@@ -0,0 +1,370 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// Sources a reload can be triggered by, as recorded in ReloadEvent.
+const (
+	// ReloadSourceManual means the reload was requested by calling Reload directly.
+	ReloadSourceManual = "manual"
+	// ReloadSourceFile means the reload was triggered by a change to the config file.
+	ReloadSourceFile = "file"
+	// ReloadSourceSignal means the reload was triggered by a signal sent to the process.
+	ReloadSourceSignal = "signal"
+	// ReloadSourceSecrets means only the secrets of the config were reloaded, by ReloadSecrets.
+	ReloadSourceSecrets = "secrets"
+)
+
+// redactedValue replaces the values of sensitive settings in the reload history.
+const redactedValue = "[REDACTED]"
+
+// ReloadEvent records a reload which changed the effective config.
+type ReloadEvent struct {
+	// Time is when the reload happened.
+	Time time.Time `json:"time"`
+	// Source is what triggered the reload, e.g. ReloadSourceFile.
+	Source string `json:"source"`
+	// ChangedKeys are the dotted keys of the settings which changed, as returned by Diff.
+	ChangedKeys []string `json:"changed_keys"`
+	// Changes hold the previous and new value of every changed setting.
+	// The values of sensitive settings and of resolved secrets are redacted.
+	Changes []Change `json:"changes"`
+}
+
+// Change holds the previous and new value of a setting changed by a reload.
+type Change struct {
+	Key string `json:"key"`
+	Old any    `json:"old"`
+	New any    `json:"new"`
+}
+
+// Watcher holds the config loaded from a file, and reloads it on request,
+// keeping a history of the reloads which changed it.
+type Watcher struct {
+	path        string
+	historyFile string
+	now         func() time.Time
+	debounce    time.Duration
+
+	// debounceMu guards debounceTimer, which delays reloads triggered by NotifyChange.
+	debounceMu    sync.Mutex
+	debounceTimer *time.Timer
+
+	// reloadMu serializes reloads, so that changes are observed and recorded in order.
+	reloadMu sync.Mutex
+	mu       sync.RWMutex
+	current  *Config
+	// checksum is the checksum of the config file content current was loaded from.
+	checksum [sha256.Size]byte
+	history  []ReloadEvent
+	watchers map[int]keyWatcher
+	nextID   int
+}
+
+// keyWatcher is a subscription to the changes of a single setting.
+type keyWatcher struct {
+	path     string
+	onChange func(oldValue, newValue any)
+}
+
+// WatcherOption configures a Watcher.
+type WatcherOption func(*Watcher)
+
+// WithHistoryFile persists the reload history to the given file as JSON lines, so that it
+// survives restarts. Events already in the file are loaded into the history when the Watcher is created.
+func WithHistoryFile(path string) WatcherOption {
+	return func(w *Watcher) {
+		w.historyFile = path
+	}
+}
+
+// NewWatcher loads the config at configPath, as LoadOrCreateConfigWithPath does, and returns a
+// Watcher which can reload it.
+func NewWatcher(configPath string, opts ...WatcherOption) (*Watcher, error) {
+	w := &Watcher{path: configPath, now: time.Now, debounce: reloadDebounce()}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if w.historyFile != "" {
+		history, err := readHistory(w.historyFile)
+		if err != nil {
+			return nil, err
+		}
+		w.history = history
+	}
+
+	// The checksum is taken first, so that a change made while loading causes a later reload.
+	w.checksum, _ = fileChecksum(configPath)
+	config, err := LoadOrCreateConfigWithPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	w.current = config
+	return w, nil
+}
+
+// Config returns the most recently loaded config. It must not be modified.
+func (w *Watcher) Config() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Reload loads the config file again and returns the keys of the settings which changed.
+// If the new config fails to load or validate, the current config is kept and the error is returned,
+// as is ErrConfigSealed if the current config is sealed without allowing reloads. Reloads which change
+// at least one setting are recorded in the history along with source, and logged at info level as a
+// single entry listing the old and new value of every changed setting.
+func (w *Watcher) Reload(source string) ([]string, error) {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+	return w.reloadLocked(source)
+}
+
+// reloadLocked reloads the config as Reload does. The caller must hold reloadMu.
+func (w *Watcher) reloadLocked(source string) ([]string, error) {
+	checksum, _ := fileChecksum(w.path)
+	config, err := LoadOrCreateConfigWithPath(w.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload config: %w", err)
+	}
+	return w.replaceLocked(source, config, checksum)
+}
+
+// ReloadSecrets resolves the secrets and file references of the current config again, and swaps in
+// their new values without reloading the rest of the config file, so that rotated credentials are
+// picked up without disturbing other settings. It returns the keys of the settings which changed.
+// If a secret fails to resolve or the new values fail to validate, the current config is kept and
+// the error is returned. Changes are recorded, logged and notified as Reload does, with
+// ReloadSourceSecrets as their source, so only subscribers of the rotated settings are called.
+func (w *Watcher) ReloadSecrets() ([]string, error) {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	w.mu.RLock()
+	config, checksum := w.current.clone(), w.checksum
+	w.mu.RUnlock()
+	if err := config.refreshSecrets(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to reload secrets: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to reload secrets: invalid config: %w", err)
+	}
+	return w.replaceLocked(ReloadSourceSecrets, config, checksum)
+}
+
+// replaceLocked makes config, loaded from a config file with the given checksum, the current config,
+// recording, logging and notifying its changes. If the current config is sealed, config is sealed
+// with the same options, and is only swapped in if they allow reloads. The caller must hold reloadMu.
+func (w *Watcher) replaceLocked(source string, config *Config, checksum [sha256.Size]byte) ([]string, error) {
+	w.mu.Lock()
+	previous := w.current
+	if previous.seal != nil {
+		if !previous.seal.AllowReload {
+			w.mu.Unlock()
+			return nil, fmt.Errorf("refusing to reload config: %w", ErrConfigSealed)
+		}
+		seal := *previous.seal
+		config.seal = &seal
+	}
+	w.current = config
+	w.checksum = checksum
+	changed := Diff(previous, config)
+	var changes []Change
+	var historyErr error
+	if len(changed) > 0 {
+		changes = redactedChanges(previous, config, changed)
+		historyErr = w.recordLocked(ReloadEvent{
+			Time:        w.now(),
+			Source:      source,
+			ChangedKeys: changed,
+			Changes:     changes,
+		})
+	}
+	watchers := make([]keyWatcher, 0, len(w.watchers))
+	for _, watcher := range w.watchers {
+		watchers = append(watchers, watcher)
+	}
+	w.mu.Unlock()
+
+	if len(changes) > 0 {
+		logger.Infow("config changed", "source", source, "changes", changes)
+	}
+
+	// Callbacks run without holding mu, so that they may read the config or manage subscriptions.
+	for _, watcher := range watchers {
+		oldValue, newValue := settingAt(previous, watcher.path), settingAt(config, watcher.path)
+		if !reflect.DeepEqual(oldValue, newValue) {
+			watcher.onChange(oldValue, newValue)
+		}
+	}
+	return changed, historyErr
+}
+
+// WatchKey calls onChange with the previous and new value of the setting at the given dotted path,
+// e.g. "otel.endpoint" or "features.beta", whenever a reload changes it. Changes to other settings
+// are ignored. Callbacks run on the goroutine calling Reload, once the reload has been recorded.
+// Calling the returned function cancels the subscription.
+func (w *Watcher) WatchKey(path string, onChange func(oldValue, newValue any)) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers == nil {
+		w.watchers = map[int]keyWatcher{}
+	}
+	id := w.nextID
+	w.nextID++
+	w.watchers[id] = keyWatcher{path: strings.ToLower(path), onChange: onChange}
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers, id)
+	}
+}
+
+// ConfigHistory returns the reloads which changed the config, oldest first.
+func (w *Watcher) ConfigHistory() []ReloadEvent {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]ReloadEvent{}, w.history...)
+}
+
+// Diff returns the dotted keys of the settings whose values differ between oldConfig and newConfig,
+// in schema order. Empty and absent lists or maps are considered equal.
+func Diff(oldConfig, newConfig *Config) []string {
+	oldValue := reflect.ValueOf(oldConfig).Elem()
+	newValue := reflect.ValueOf(newConfig).Elem()
+
+	var changed []string
+	for _, field := range schemaFields() {
+		if !equalSettings(oldValue.FieldByIndex(field.Index), newValue.FieldByIndex(field.Index)) {
+			changed = append(changed, field.Key)
+		}
+	}
+	return changed
+}
+
+func equalSettings(a, b reflect.Value) bool {
+	if kind := a.Kind(); (kind == reflect.Slice || kind == reflect.Map) && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// recordLocked adds event to the history, persisting it if a history file is configured.
+// The caller must hold mu.
+func (w *Watcher) recordLocked(event ReloadEvent) error {
+	w.history = append(w.history, event)
+	if w.historyFile == "" {
+		return nil
+	}
+	return appendHistory(w.historyFile, event)
+}
+
+// settingAt returns the value of the setting at the given dotted path, which is either a schema key
+// or the key of an entry within a map setting, e.g. "features.beta". It returns nil if there is no such setting.
+func settingAt(c *Config, path string) any {
+	value := reflect.ValueOf(c).Elem()
+	for _, field := range schemaFields() {
+		setting := value.FieldByIndex(field.Index)
+		if path == field.Key {
+			return setting.Interface()
+		}
+		name, ok := strings.CutPrefix(path, field.Key+".")
+		if !ok || setting.Kind() != reflect.Map || setting.Type().Key().Kind() != reflect.String {
+			continue
+		}
+		for _, key := range setting.MapKeys() {
+			if strings.EqualFold(key.String(), name) {
+				return setting.MapIndex(key).Interface()
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// redactedChanges returns the changes of the given keys between two configs, redacting sensitive values.
+func redactedChanges(oldConfig, newConfig *Config, keys []string) []Change {
+	changes := make([]Change, 0, len(keys))
+	for _, key := range keys {
+		change := Change{Key: key, Old: settingAt(oldConfig, key), New: settingAt(newConfig, key)}
+		if isSensitiveValue(key, change.Old) || isSensitiveValue(key, change.New) ||
+			oldConfig.holdsResolvedSecret(key) || newConfig.holdsResolvedSecret(key) {
+			change.Old, change.New = redactedValue, redactedValue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// fileChecksum returns the checksum of the content of the file at path.
+func fileChecksum(path string) ([sha256.Size]byte, error) {
+	// #nosec G304: The config file is provided by the caller.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+func readHistory(path string) ([]ReloadEvent, error) {
+	// #nosec G304: The history file is provided by the caller.
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config history %s: %w", path, err)
+	}
+
+	var history []ReloadEvent
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var event ReloadEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to parse config history %s: %w", path, err)
+		}
+		history = append(history, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read config history %s: %w", path, err)
+	}
+	return history, nil
+}
+
+func appendHistory(path string, event ReloadEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize config history: %w", err)
+	}
+
+	// #nosec G304: The history file is provided by the caller.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open config history %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write config history %s: %w", path, err)
+	}
+	return nil
+}
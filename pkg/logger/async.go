@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// AsyncBufferSizeEnvVar is the environment variable holding the number of entries which may be
+// queued for writing in the background. Entries logged while the queue is full are dropped and
+// counted in Stats. Zero or unset writes entries synchronously.
+const AsyncBufferSizeEnvVar = "LOG_ASYNC_BUFFER_SIZE"
+
+// asyncWriteSyncer writes to the wrapped syncer from a background goroutine, dropping
+// entries rather than blocking the caller when the queue is full.
+type asyncWriteSyncer struct {
+	ws    zapcore.WriteSyncer
+	queue chan asyncItem
+}
+
+// asyncItem is either an entry to write, or a sync request answered on synced
+// once every entry queued before it has been written.
+type asyncItem struct {
+	entry  []byte
+	synced chan error
+}
+
+func newAsyncWriteSyncer(ws zapcore.WriteSyncer, size int) *asyncWriteSyncer {
+	w := &asyncWriteSyncer{ws: ws, queue: make(chan asyncItem, size)}
+	go w.run()
+	return w
+}
+
+func (w *asyncWriteSyncer) Write(p []byte) (int, error) {
+	// The encoder reuses p once Write returns, so the entry must be copied before queueing it.
+	item := asyncItem{entry: append([]byte{}, p...)}
+	select {
+	case w.queue <- item:
+	default:
+		droppedEntries.Add(1)
+	}
+	return len(p), nil
+}
+
+// Sync blocks until every entry queued before it has been written, then syncs the wrapped syncer.
+func (w *asyncWriteSyncer) Sync() error {
+	synced := make(chan error, 1)
+	w.queue <- asyncItem{synced: synced}
+	return <-synced
+}
+
+func (w *asyncWriteSyncer) run() {
+	for item := range w.queue {
+		if item.synced != nil {
+			item.synced <- w.ws.Sync()
+			continue
+		}
+		// Errors cannot be reported to the caller, which has already returned.
+		_, _ = w.ws.Write(item.entry)
+	}
+}
+
+// asyncBufferSize returns the async buffer size configured in the environment, or zero if disabled.
+func asyncBufferSize() int {
+	size, err := strconv.Atoi(os.Getenv(AsyncBufferSizeEnvVar))
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BudgetPerMinuteEnvVar is the environment variable holding the number of entries the logger emits
+// per minute before throttling lower-priority levels: debug entries are dropped once 80% of the
+// budget has been used within a minute, and info entries once all of it has. Entries at warn level
+// and above are always emitted. Dropped entries are counted in Stats. Zero or unset disables the budget.
+const BudgetPerMinuteEnvVar = "LOG_BUDGET_PER_MIN"
+
+const (
+	budgetWindow = time.Minute
+	// budgetDebugShare is the share of the budget after which debug entries are dropped.
+	budgetDebugShare = 0.8
+)
+
+// budgetState counts the entries emitted within the current window across a logger and all
+// loggers derived from it.
+type budgetState struct {
+	budget int
+
+	mu          sync.Mutex
+	windowStart time.Time
+	emitted     int
+	throttled   bool
+}
+
+// admit reports whether an entry at the given level logged at t fits in the budget, counting it if
+// so. The second value is true for the first entry dropped within a window.
+func (s *budgetState) admit(level zapcore.Level, t time.Time) (admitted bool, throttling bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.Sub(s.windowStart) >= budgetWindow {
+		s.windowStart, s.emitted, s.throttled = t, 0, false
+	}
+
+	limit := s.budget
+	switch {
+	case level >= zapcore.WarnLevel:
+		limit = -1
+	case level < zapcore.InfoLevel:
+		limit = int(float64(s.budget) * budgetDebugShare)
+	}
+	if limit >= 0 && s.emitted >= limit {
+		throttling = !s.throttled
+		s.throttled = true
+		return false, throttling
+	}
+	s.emitted++
+	return true, false
+}
+
+// budgetCore drops debug and then info entries as the entries emitted within a minute approach the
+// budget, emitting a single notice each minute in which it starts dropping entries.
+type budgetCore struct {
+	zapcore.Core
+	state *budgetState
+}
+
+func newBudgetCore(core zapcore.Core, budget int) zapcore.Core {
+	return &budgetCore{Core: core, state: &budgetState{budget: budget}}
+}
+
+func (c *budgetCore) With(fields []zapcore.Field) zapcore.Core {
+	return &budgetCore{Core: c.Core.With(fields), state: c.state}
+}
+
+func (c *budgetCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	admitted, throttling := c.state.admit(ent.Level, ent.Time)
+	if throttling {
+		c.notify(ent)
+	}
+	if !admitted {
+		droppedEntries.Add(1)
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// notify logs that entries are being dropped, dropped being the first one dropped within the window.
+func (c *budgetCore) notify(dropped zapcore.Entry) {
+	if !c.Core.Enabled(zapcore.WarnLevel) {
+		return
+	}
+	ent := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    dropped.Time,
+		Message: "log volume budget reached, dropping low-priority entries for the rest of the minute",
+	}
+	_ = c.Core.Write(ent, []zapcore.Field{
+		zap.Int("budget_per_minute", c.state.budget),
+		zap.Stringer("first_dropped_level", dropped.Level),
+	})
+}
+
+// logBudget returns the entries per minute budget configured in the environment, or zero if disabled.
+func logBudget() int {
+	budget, err := strconv.Atoi(os.Getenv(BudgetPerMinuteEnvVar))
+	if err != nil || budget < 0 {
+		return 0
+	}
+	return budget
+}
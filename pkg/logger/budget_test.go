@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const budgetNotice = "log volume budget reached, dropping low-priority entries for the rest of the minute"
+
+func TestBudgetCore(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.DebugLevel)
+	clock := newFakeClock()
+	log := zap.New(newBudgetCore(core, 10), zap.WithClock(clock))
+
+	// Debug entries are dropped once 8 entries have been emitted, info entries once 10 have.
+	for range 6 {
+		log.Info("request served")
+	}
+	for range 5 {
+		log.Debug("cache hit")
+	}
+	for range 5 {
+		log.Info("request served")
+	}
+	for range 3 {
+		log.Error("request failed")
+	}
+
+	assert.Equal(t, 2, logs.FilterMessage("cache hit").Len(), "debug entries are dropped first")
+	assert.Equal(t, 8, logs.FilterMessage("request served").Len())
+	assert.Equal(t, 3, logs.FilterMessage("request failed").Len(), "errors are never dropped")
+	notices := logs.FilterMessage(budgetNotice).All()
+	require.Len(t, notices, 1, "a single notice is emitted when throttling engages")
+	assert.Equal(t, zapcore.WarnLevel, notices[0].Level)
+	assert.Equal(t, map[string]any{"budget_per_minute": int64(10), "first_dropped_level": "debug"}, notices[0].ContextMap())
+
+	// The budget is renewed every minute.
+	clock.Advance(time.Minute)
+	log.Debug("cache hit")
+	assert.Equal(t, 3, logs.FilterMessage("cache hit").Len())
+	for range 10 {
+		log.Info("request served")
+	}
+	assert.Equal(t, 2, logs.FilterMessage(budgetNotice).Len(), "the notice is emitted again in a new minute")
+}
+
+func TestLogBudget(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(BudgetPerMinuteEnvVar, "")
+	assert.Zero(t, logBudget())
+	t.Setenv(BudgetPerMinuteEnvVar, "invalid")
+	assert.Zero(t, logBudget())
+	t.Setenv(BudgetPerMinuteEnvVar, "10000")
+	assert.Equal(t, 10000, logBudget())
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// CallerPackageEnvVar is the environment variable which, when set to true, adds the import path
+	// of the calling package to every entry, for grouping entries more coarsely than by caller.
+	// Entries only carry it when caller information is recorded, which unstructured logs disable.
+	CallerPackageEnvVar = "LOG_CALLER_PKG"
+	// CallerPackageKey is the field holding the import path of the calling package.
+	CallerPackageKey = "pkg"
+)
+
+// callerPackageCore adds the import path of the package of the caller to every entry.
+type callerPackageCore struct {
+	zapcore.Core
+}
+
+func newCallerPackageCore(core zapcore.Core) zapcore.Core {
+	return &callerPackageCore{Core: core}
+}
+
+func (c *callerPackageCore) With(fields []zapcore.Field) zapcore.Core {
+	return &callerPackageCore{Core: c.Core.With(fields)}
+}
+
+func (c *callerPackageCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *callerPackageCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	pkg := callerPackage(ent.Caller.Function)
+	if pkg == "" {
+		return c.Core.Write(ent, fields)
+	}
+	return c.Core.Write(ent, append(fields[:len(fields):len(fields)], zap.String(CallerPackageKey, pkg)))
+}
+
+// callerPackage returns the import path of the package of the fully qualified function name,
+// e.g. "github.com/stacklok/toolhive/pkg/api" for "github.com/stacklok/toolhive/pkg/api.(*Server).Start".
+func callerPackage(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+	return function[:lastSlash+1+dot]
+}
+
+// callerPackageEnabled reports whether the caller package has been enabled through CallerPackageEnvVar.
+func callerPackageEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(CallerPackageEnvVar))
+	return err == nil && enabled
+}
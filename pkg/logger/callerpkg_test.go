@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const loggerPackage = "github.com/stacklok/toolhive/pkg/logger"
+
+func TestCallerPackageCore(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newCallerPackageCore(core), zap.AddCaller())
+
+	log.With(zap.String("component", "proxy")).Info("message")
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{"component": "proxy", CallerPackageKey: loggerPackage}, entries[0].ContextMap())
+}
+
+func TestCallerPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		function string
+		expected string
+	}{
+		{"github.com/stacklok/toolhive/pkg/api.(*Server).Start", "github.com/stacklok/toolhive/pkg/api"},
+		{"github.com/stacklok/toolhive/pkg/logger.TestCallerPackage.func1", loggerPackage},
+		{"main.main", "main"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, callerPackage(tt.function), tt.function)
+	}
+}
+
+func TestCallerPackageFromEnv(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(CallerPackageEnvVar, "true")
+	entry := logStructuredEntry(t)
+	assert.Equal(t, loggerPackage, entry[CallerPackageKey])
+
+	t.Setenv(CallerPackageEnvVar, "false")
+	entry = logStructuredEntry(t)
+	assert.NotContains(t, entry, CallerPackageKey)
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// FieldKeyLimitEnvVar is the environment variable holding the maximum number of distinct
+	// field keys the logger emits. Zero or unset disables the guard.
+	FieldKeyLimitEnvVar = "LOG_FIELD_KEY_LIMIT"
+	// DroppedFieldsKey is the field counting the fields dropped from an entry by the guard.
+	DroppedFieldsKey = "dropped_fields"
+)
+
+// cardinalityGuard tracks distinct field keys across a logger and all loggers derived from it.
+type cardinalityGuard struct {
+	mu     sync.Mutex
+	limit  int
+	keys   map[string]struct{}
+	warned bool
+}
+
+// admit reports whether a field with the given key may be emitted, recording new keys while
+// below the limit. The second value is true the first time a key is refused.
+func (g *cardinalityGuard) admit(key string) (admitted bool, firstRefusal bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.keys[key]; ok {
+		return true, false
+	}
+	if len(g.keys) < g.limit {
+		g.keys[key] = struct{}{}
+		return true, false
+	}
+	firstRefusal = !g.warned
+	g.warned = true
+	return false, firstRefusal
+}
+
+// cardinalityCore drops fields whose keys would exceed the distinct key limit, protecting log
+// backends from index explosions caused by unbounded keys such as user IDs. The first time a
+// key is refused, a single warning is emitted.
+type cardinalityCore struct {
+	zapcore.Core
+	guard *cardinalityGuard
+}
+
+func newCardinalityCore(core zapcore.Core, limit int) zapcore.Core {
+	return &cardinalityCore{
+		Core:  core,
+		guard: &cardinalityGuard{limit: limit, keys: map[string]struct{}{}},
+	}
+}
+
+func (c *cardinalityCore) With(fields []zapcore.Field) zapcore.Core {
+	admitted, _ := c.filter(fields)
+	return &cardinalityCore{Core: c.Core.With(admitted), guard: c.guard}
+}
+
+func (c *cardinalityCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *cardinalityCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	admitted, dropped := c.filter(fields)
+	if dropped > 0 {
+		admitted = append(admitted, zap.Int(DroppedFieldsKey, dropped))
+	}
+	return c.Core.Write(ent, admitted)
+}
+
+// filter returns the admitted fields and the number of fields dropped.
+func (c *cardinalityCore) filter(fields []zapcore.Field) ([]zapcore.Field, int) {
+	admitted := make([]zapcore.Field, 0, len(fields))
+	dropped := 0
+	for _, field := range fields {
+		ok, firstRefusal := c.guard.admit(field.Key)
+		if firstRefusal {
+			c.warn(field.Key)
+		}
+		if !ok {
+			dropped++
+			continue
+		}
+		admitted = append(admitted, field)
+	}
+	return admitted, dropped
+}
+
+func (c *cardinalityCore) warn(key string) {
+	ent := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    now(),
+		Message: "distinct log field key limit reached, dropping fields with new keys",
+	}
+	if c.Enabled(ent.Level) {
+		_ = c.Core.Write(ent, []zapcore.Field{zap.Int("limit", c.guard.limit), zap.String("first_dropped_key", key)})
+	}
+}
+
+// fieldKeyLimit returns the distinct field key limit configured in the environment, or zero if disabled.
+func fieldKeyLimit() int {
+	limit, err := strconv.Atoi(os.Getenv(FieldKeyLimitEnvVar))
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
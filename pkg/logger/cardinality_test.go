@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCardinalityCore(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := zap.New(newCardinalityCore(core, 3)).Sugar()
+
+	child := l.With("component", "test")
+	for i := range 10 {
+		child.Infow("user logged in", fmt.Sprintf("user_%d", i), true)
+	}
+	child.Infow("known keys still pass", "user_0", false)
+
+	warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+	require.Len(t, warnings, 1, "exactly one warning is emitted")
+	assert.Equal(t, "user_2", warnings[0].ContextMap()["first_dropped_key"])
+
+	entries := logs.FilterLevelExact(zapcore.InfoLevel).All()
+	require.Len(t, entries, 11)
+	assert.Equal(t, map[string]any{"component": "test", "user_0": true}, entries[0].ContextMap())
+	assert.Equal(t, map[string]any{"component": "test", "user_1": true}, entries[1].ContextMap())
+	for _, entry := range entries[2:10] {
+		assert.Equal(t, map[string]any{"component": "test", DroppedFieldsKey: int64(1)}, entry.ContextMap())
+	}
+	assert.Equal(t, map[string]any{"component": "test", "user_0": false}, entries[10].ContextMap())
+}
+
+func TestCardinalityCoreWith(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := zap.New(newCardinalityCore(core, 1))
+
+	l.With(zap.String("first", "kept"), zap.String("second", "dropped")).Info("message")
+
+	entries := logs.FilterLevelExact(zapcore.InfoLevel).All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{"first": "kept"}, entries[0].ContextMap())
+	assert.Equal(t, 1, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RetryableKey is the field added to entries whose error could be classified as retryable or permanent.
+const RetryableKey = "retryable"
+
+// ErrorClassifier reports whether an error is retryable.
+// The second return value must be false when the classifier does not recognise the error.
+type ErrorClassifier func(err error) (retryable bool, ok bool)
+
+var (
+	classifiers     []ErrorClassifier
+	classifiersLock = &sync.RWMutex{}
+)
+
+// RegisterErrorClassifier registers a classifier used to decide whether logged errors are retryable.
+// Errors implementing Temporary() bool are classified without a registered classifier.
+func RegisterErrorClassifier(classifier ErrorClassifier) {
+	classifiersLock.Lock()
+	defer classifiersLock.Unlock()
+	classifiers = append(classifiers, classifier)
+}
+
+// IsRetryable reports whether err is retryable, and whether it could be classified at all.
+// Errors implementing Temporary() bool anywhere in their chain take precedence over
+// registered classifiers, which are consulted in registration order.
+func IsRetryable(err error) (retryable bool, ok bool) {
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) {
+		return temporary.Temporary(), true
+	}
+
+	classifiersLock.RLock()
+	defer classifiersLock.RUnlock()
+	for _, classifier := range classifiers {
+		if retryable, ok := classifier(err); ok {
+			return retryable, true
+		}
+	}
+	return false, false
+}
+
+// classifyingCore adds the retryable field to entries carrying a classifiable error.
+type classifyingCore struct {
+	zapcore.Core
+}
+
+func newClassifyingCore(core zapcore.Core) zapcore.Core {
+	return &classifyingCore{Core: core}
+}
+
+func (c *classifyingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &classifyingCore{Core: c.Core.With(classifyFields(fields))}
+}
+
+func (c *classifyingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *classifyingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, classifyFields(fields))
+}
+
+// classifyFields appends the retryable field for the first classifiable error in fields.
+func classifyFields(fields []zapcore.Field) []zapcore.Field {
+	for _, field := range fields {
+		if field.Key == RetryableKey {
+			return fields
+		}
+	}
+
+	for _, field := range fields {
+		if field.Type != zapcore.ErrorType {
+			continue
+		}
+		err, ok := field.Interface.(error)
+		if !ok || err == nil {
+			continue
+		}
+		if retryable, ok := IsRetryable(err); ok {
+			return append(fields[:len(fields):len(fields)], zap.Bool(RetryableKey, retryable))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type temporaryError struct {
+	temporary bool
+}
+
+func (temporaryError) Error() string     { return "temporary error" }
+func (e temporaryError) Temporary() bool { return e.temporary }
+
+// throttledError is only recognised through a registered classifier.
+type throttledError struct{}
+
+func (throttledError) Error() string { return "throttled" }
+
+func newClassifyingTestLogger() (*zap.SugaredLogger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(newClassifyingCore(core)).Sugar(), logs
+}
+
+func TestClassifyingCore(t *testing.T) {
+	t.Parallel()
+
+	RegisterErrorClassifier(func(err error) (bool, bool) {
+		if errors.As(err, &throttledError{}) {
+			return true, true
+		}
+		return false, false
+	})
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		present   bool
+	}{
+		{"Temporary", temporaryError{temporary: true}, true, true},
+		{"Permanent", temporaryError{temporary: false}, false, true},
+		{"WrappedTemporary", fmt.Errorf("wrapped: %w", temporaryError{temporary: true}), true, true},
+		{"RegisteredClassifier", throttledError{}, true, true},
+		{"Unclassified", errors.New("plain"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			log, logs := newClassifyingTestLogger()
+
+			log.Errorw("operation failed", "error", tt.err)
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			retryable, present := entries[0].ContextMap()[RetryableKey]
+			assert.Equal(t, tt.present, present)
+			if tt.present {
+				assert.Equal(t, tt.retryable, retryable)
+			}
+		})
+	}
+}
+
+func TestClassifyingCoreWith(t *testing.T) {
+	t.Parallel()
+	log, logs := newClassifyingTestLogger()
+
+	log.Desugar().With(zap.Error(temporaryError{temporary: true})).Warn("retrying")
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, true, entries[0].ContextMap()[RetryableKey])
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Clock tells the time for the time-based features of the logger: entry timestamps, sampling,
+// stack trace deduplication, error spike windows, Progress throttling and the durations measured
+// by StartTimer, QueryLogger and HTTPMiddleware. It defaults to the system clock.
+type Clock = zapcore.Clock
+
+// clockHolder wraps a Clock so that clocks of different types can be kept in an atomic.Value.
+type clockHolder struct {
+	clock Clock
+}
+
+var currentClock atomic.Value
+
+func init() {
+	currentClock.Store(clockHolder{clock: zapcore.DefaultClock})
+}
+
+// SetClock replaces the clock used by the logger, typically with a fake clock in tests, and returns
+// a function restoring the previous one. Loggers built by Initialize or NewLogger take their
+// timestamps from the clock set when they are built.
+func SetClock(clock Clock) (restore func()) {
+	previous := currentClock.Swap(clockHolder{clock: clock})
+	return func() {
+		currentClock.Store(previous)
+	}
+}
+
+// getClock returns the clock used by the logger.
+func getClock() Clock {
+	return currentClock.Load().(clockHolder).clock
+}
+
+// now returns the current time according to the logger's clock.
+func now() time.Time {
+	return getClock().Now()
+}
+
+// since returns the time elapsed since start according to the logger's clock.
+func since(start time.Time) time.Duration {
+	return now().Sub(start)
+}
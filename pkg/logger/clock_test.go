@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeClock is a Clock which only moves when advanced.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (*fakeClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestClockProgress(t *testing.T) { //nolint:paralleltest // Replaces the global clock
+	clock := newFakeClock()
+	defer SetClock(clock)()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	// Keys are shared by the process, so a key of its own keeps the test repeatable.
+	key := fmt.Sprintf("TestClockProgress-%d", time.Now().UnixNano())
+	const every = time.Minute
+	l.Progress(key, "copying layers", every)
+	clock.Advance(every - time.Nanosecond)
+	l.Progress(key, "copying layers", every)
+	assert.Equal(t, 1, logs.Len(), "entries are throttled until the interval has passed")
+
+	clock.Advance(time.Nanosecond)
+	l.Progress(key, "copying layers", every)
+	assert.Equal(t, 2, logs.Len(), "an entry is emitted exactly once the interval has passed")
+}
+
+func TestClockStackDedup(t *testing.T) {
+	t.Parallel()
+	clock := newFakeClock()
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newStackDedupCore(core, time.Minute), zap.AddStacktrace(zapcore.ErrorLevel), zap.WithClock(clock))
+
+	// Failing from a single call site keeps the stack identical.
+	for _, advance := range []time.Duration{0, time.Minute - time.Nanosecond, time.Nanosecond} {
+		clock.Advance(advance)
+		failRepeatedly(log, 1)
+	}
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	assert.NotEmpty(t, entries[0].Stack)
+	assert.Empty(t, entries[1].Stack, "the stack is omitted within the window")
+	assert.NotEmpty(t, entries[2].Stack, "the stack is emitted again exactly once the window has passed")
+}
+
+func TestClockStartTimer(t *testing.T) { //nolint:paralleltest // Replaces the global clock
+	clock := newFakeClock()
+	defer SetClock(clock)()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	done := l.StartTimer("pull image", 30*time.Second)
+	clock.Advance(30*time.Second - time.Nanosecond)
+	done()
+	done = l.StartTimer("pull image", 30*time.Second)
+	clock.Advance(30 * time.Second)
+	done()
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, 30*time.Second-time.Nanosecond, entries[0].ContextMap()["elapsed"])
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, 30*time.Second, entries[1].ContextMap()["elapsed"])
+}
+
+func TestClockTimestamps(t *testing.T) { //nolint:paralleltest // Replaces the global clock
+	clock := newFakeClock()
+	defer SetClock(clock)()
+
+	entry := logStructuredEntry(t)
+	assert.Equal(t, float64(clock.Now().UnixNano())/float64(time.Second), entry["ts"])
+}
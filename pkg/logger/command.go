@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// sensitiveArgNames are the words which mark a command-line flag, or a NAME=value argument such as an
+// environment variable passed to a container, as holding a secret. They are matched case-insensitively
+// anywhere in the name.
+var sensitiveArgNames = []string{
+	"password", "passwd", "secret", "token", "api_key", "apikey", "credential", "private_key", "authorization",
+}
+
+// LogCommand logs the execution of cmd, which returned err after running for d: the executable, the
+// arguments with the values of sensitive flags and NAME=value arguments redacted, the exit code when the
+// command ran, and the duration. It is logged at info level if the command succeeded, and at error level
+// with err otherwise.
+//
+//	start := time.Now()
+//	err := cmd.Run()
+//	logger.LogCommand(l, cmd, err, time.Since(start))
+func LogCommand(l *Logger, cmd *exec.Cmd, err error, d time.Duration) {
+	// Skip LogCommand, so that entries report the caller which ran the command.
+	logger := l.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+	fields := []any{zap.String("executable", cmd.Path), zap.Strings("args", sanitizeArgs(cmd.Args))}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		fields = append(fields, zap.Int("exit_code", exitErr.ExitCode()))
+	case cmd.ProcessState != nil:
+		fields = append(fields, zap.Int("exit_code", cmd.ProcessState.ExitCode()))
+	}
+	fields = append(fields, zap.Duration("duration", d))
+
+	if err != nil {
+		logger.Errorw("command failed", append(fields, zap.Error(err))...)
+		return
+	}
+	logger.Infow("command completed", fields...)
+}
+
+// sanitizeArgs returns the arguments of a command, without the command name, with the values of
+// sensitive flags redacted, whether given as --flag=value or as the argument following --flag, and
+// the values of sensitive NAME=value arguments redacted.
+func sanitizeArgs(args []string) []string {
+	if len(args) <= 1 {
+		return nil
+	}
+	sanitized := make([]string, 0, len(args)-1)
+	redactNext := false
+	for _, arg := range args[1:] {
+		if redactNext {
+			sanitized = append(sanitized, redactedBodyValue)
+			redactNext = false
+			continue
+		}
+		name, _, hasValue := strings.Cut(arg, "=")
+		isFlag := strings.HasPrefix(name, "-")
+		switch {
+		case !sensitiveArgName(name):
+			sanitized = append(sanitized, arg)
+		case hasValue:
+			sanitized = append(sanitized, name+"="+redactedBodyValue)
+		default:
+			sanitized = append(sanitized, arg)
+			redactNext = isFlag
+		}
+	}
+	return sanitized
+}
+
+// sensitiveArgName reports whether a flag or variable name marks its value as holding a secret.
+func sensitiveArgName(name string) bool {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimLeft(name, "-")), "-", "_")
+	for _, sensitive := range sensitiveArgNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		cmd := exec.Command("sh", "-c", "exit 0", "--token", "s3cret")
+
+		LogCommand(l, cmd, cmd.Run(), 150*time.Millisecond)
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+		assert.Equal(t, "command completed", entries[0].Message)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, cmd.Path, fields["executable"])
+		assert.Equal(t, []any{"-c", "exit 0", "--token", "[REDACTED]"}, fields["args"])
+		assert.Equal(t, int64(0), fields["exit_code"])
+		assert.Equal(t, 150*time.Millisecond, fields["duration"])
+		assert.NotContains(t, fields, "error")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		cmd := exec.Command("sh", "-c", "exit 3")
+
+		LogCommand(l, cmd, cmd.Run(), time.Second)
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		assert.Equal(t, "command failed", entries[0].Message)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, int64(3), fields["exit_code"])
+		assert.Equal(t, time.Second, fields["duration"])
+		assert.Equal(t, "exit status 3", fields["error"])
+	})
+
+	t.Run("NotStarted", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		cmd := exec.Command("/nonexistent/command")
+
+		LogCommand(l, cmd, cmd.Run(), 0)
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		assert.NotContains(t, entries[0].ContextMap(), "exit_code")
+		assert.Contains(t, entries[0].ContextMap(), "error")
+	})
+}
+
+func TestSanitizeArgs(t *testing.T) {
+	t.Parallel()
+	args := sanitizeArgs([]string{
+		"docker", "run", "--password=hunter2", "--api-key", "abc", "-e", "GITHUB_TOKEN=ghp_x", "-e", "LOG_LEVEL=debug",
+		"--name", "fetch", "--secret",
+	})
+	assert.Equal(t, []string{
+		"run", "--password=[REDACTED]", "--api-key", "[REDACTED]", "-e", "GITHUB_TOKEN=[REDACTED]", "-e", "LOG_LEVEL=debug",
+		"--name", "fetch", "--secret",
+	}, args)
+	assert.Nil(t, sanitizeArgs([]string{"docker"}))
+}
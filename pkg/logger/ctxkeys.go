@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/zap"
+)
+
+// FromContextWithKeys returns a Logger for use with ctx, as FromContext does, which adds the values
+// stored in ctx under each of keys to every entry. Keys without a value in ctx are skipped. Each value
+// is logged under the name of its key: the result of its String method if it has one, the key itself
+// if it is a string or a type based on string, and otherwise the name of its type, so that
+//
+//	type tenantKey struct{}
+//	type ctxKey string
+//	FromContextWithKeys(ctx, tenantKey{}, ctxKey("user"))
+//
+// logs the values as the fields "tenantKey" and "user".
+func FromContextWithKeys(ctx context.Context, keys ...any) *Logger {
+	l := FromContext(ctx)
+	fields := make([]any, 0, len(keys))
+	for _, key := range keys {
+		if value := ctx.Value(key); value != nil {
+			fields = append(fields, zap.Any(contextKeyName(key), value))
+		}
+	}
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
+
+// contextKeyName returns the name of the field logging the value of a context key.
+func contextKeyName(key any) string {
+	if stringer, ok := key.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	t := reflect.TypeOf(key)
+	if t.Kind() == reflect.String {
+		return reflect.ValueOf(key).String()
+	}
+	return t.Name()
+}
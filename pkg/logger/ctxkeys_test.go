@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type tenantKey struct{}
+
+type ctxKey string
+
+func TestFromContextWithKeys(t *testing.T) { //nolint:paralleltest // Replaces the global logger
+	core, logs := observer.New(zapcore.DebugLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	ctx = context.WithValue(ctx, ctxKey("user"), "alice")
+
+	FromContextWithKeys(ctx, tenantKey{}, ctxKey("user"), ctxKey("request_id")).Info("listing servers")
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{"tenantKey": "acme", "user": "alice"}, entries[0].ContextMap())
+}
+
+func TestContextKeyName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tenantKey", contextKeyName(tenantKey{}))
+	assert.Equal(t, "user", contextKeyName(ctxKey("user")))
+	assert.Equal(t, "tenant", contextKeyName("tenant"))
+	assert.Equal(t, "debugTraceKey", contextKeyName(debugTraceKey{}))
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DebugTraceHeader is the request header which, when set to a true value, causes every
+// entry logged for that request to be emitted at debug level and without sampling.
+const DebugTraceHeader = "X-Debug-Trace"
+
+type debugTraceKey struct{}
+
+// WithDebugTrace returns a copy of ctx flagged so that loggers derived from it log in full.
+func WithDebugTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, debugTraceKey{}, true)
+}
+
+// IsDebugTrace reports whether ctx has been flagged by WithDebugTrace.
+func IsDebugTrace(ctx context.Context) bool {
+	traced, _ := ctx.Value(debugTraceKey{}).(bool)
+	return traced
+}
+
+// DebugTraceMiddleware flags the context of requests carrying a true X-Debug-Trace header,
+// so that loggers obtained through FromContext log those requests in full.
+func DebugTraceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if traced, err := strconv.ParseBool(r.Header.Get(DebugTraceHeader)); err == nil && traced {
+			r = r.WithContext(WithDebugTrace(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// FromContext returns a Logger backed by the singleton logger for use with ctx.
+// See (*Logger).ForContext for how flagged contexts are handled.
+func FromContext(ctx context.Context) *Logger {
+	return (&Logger{SugaredLogger: zap.S()}).ForContext(ctx)
+}
+
+// ForContext returns a Logger for use with ctx. If ctx has been flagged by WithDebugTrace,
+// the returned Logger emits every entry at debug level and above, bypassing both the
+// configured level and sampling. Otherwise l is returned unchanged.
+func (l *Logger) ForContext(ctx context.Context) *Logger {
+	if !IsDebugTrace(ctx) {
+		return l
+	}
+	return &Logger{SugaredLogger: l.WithOptions(zap.WrapCore(newDebugTraceCore))}
+}
+
+// debugTraceCore enables every entry at debug level and above. Since it writes to the
+// wrapped core directly, any filtering the wrapped core performs in Check, such as
+// level checks and sampling, is bypassed.
+type debugTraceCore struct {
+	zapcore.Core
+}
+
+func newDebugTraceCore(core zapcore.Core) zapcore.Core {
+	return &debugTraceCore{Core: core}
+}
+
+func (*debugTraceCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.DebugLevel
+}
+
+func (c *debugTraceCore) With(fields []zapcore.Field) zapcore.Core {
+	return &debugTraceCore{Core: c.Core.With(fields)}
+}
+
+// Unwrap returns the wrapped core.
+func (c *debugTraceCore) Unwrap() zapcore.Core {
+	return c.Core
+}
+
+func (c *debugTraceCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDebugTraceMiddleware(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	// Keep only the first entry of each message, so sampling is easy to observe.
+	sampled := zapcore.NewSamplerWithOptions(core, time.Minute, 1, 0)
+	l := &Logger{SugaredLogger: zap.New(sampled).Sugar()}
+
+	handler := DebugTraceMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		requestLogger := l.ForContext(r.Context()).With("request", r.URL.Path)
+		for range 3 {
+			requestLogger.Debug("processing")
+			requestLogger.Info("processed")
+		}
+	}))
+
+	normal := httptest.NewRequest(http.MethodGet, "/normal", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), normal)
+
+	traced := httptest.NewRequest(http.MethodGet, "/traced", nil)
+	traced.Header.Set(DebugTraceHeader, "true")
+	handler.ServeHTTP(httptest.NewRecorder(), traced)
+
+	normalLogs := logs.FilterField(zap.String("request", "/normal"))
+	require.Equal(t, 1, normalLogs.Len(), "normal requests are sampled and skip debug entries")
+	assert.Equal(t, zapcore.InfoLevel, normalLogs.All()[0].Level)
+
+	tracedLogs := logs.FilterField(zap.String("request", "/traced"))
+	assert.Equal(t, 3, tracedLogs.FilterLevelExact(zapcore.DebugLevel).Len())
+	assert.Equal(t, 3, tracedLogs.FilterLevelExact(zapcore.InfoLevel).Len())
+}
+
+func TestDebugTraceContext(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsDebugTrace(context.Background()))
+	assert.True(t, IsDebugTrace(WithDebugTrace(context.Background())))
+
+	l, _ := newObservedLogger(zapcore.InfoLevel)
+	assert.Same(t, l, l.ForContext(context.Background()))
+
+	traced := l.ForContext(WithDebugTrace(context.Background()))
+	assert.True(t, traced.Desugar().Core().Enabled(zapcore.DebugLevel))
+
+	invalidHeader := httptest.NewRequest(http.MethodGet, "/", nil)
+	invalidHeader.Header.Set(DebugTraceHeader, "please")
+	DebugTraceMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.False(t, IsDebugTrace(r.Context()))
+	})).ServeHTTP(httptest.NewRecorder(), invalidHeader)
+}
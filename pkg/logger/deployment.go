@@ -0,0 +1,17 @@
+package logger
+
+import "os"
+
+const (
+	// DeploymentEnvVar is the environment variable holding the deployment slot the process runs in,
+	// e.g. "blue", "green" or "canary", so that the logs of blue-green and canary deployments can be compared.
+	DeploymentEnvVar = "TOOLHIVE_DEPLOYMENT"
+
+	// DeploymentKey is the key of the field holding the deployment slot.
+	DeploymentKey = "deployment"
+)
+
+// deployment returns the deployment slot to add to every entry, or an empty string if there is none.
+func deployment() string {
+	return os.Getenv(DeploymentEnvVar)
+}
@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployment(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Run("Set", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(DeploymentEnvVar, "canary")
+
+		entry := logStructuredEntry(t)
+		assert.Equal(t, "canary", entry[DeploymentKey])
+	})
+
+	t.Run("Unset", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(DeploymentEnvVar, "")
+
+		entry := logStructuredEntry(t)
+		assert.NotContains(t, entry, DeploymentKey)
+	})
+}
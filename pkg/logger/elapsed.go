@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ElapsedKey is the key of the field added by Since.
+const ElapsedKey = "elapsed"
+
+// Since returns an "elapsed" field holding the time elapsed since start, measured with the monotonic
+// clock reading Go embeds in times returned by time.Now, so that the duration is accurate even if the
+// wall clock is adjusted in between. Unlike the durations measured by StartTimer, it always uses the
+// system clock rather than the one set by SetClock. A start without a monotonic reading, such as a
+// time which was parsed or rounded, falls back to the wall clock.
+//
+//	start := time.Now()
+//	handle(req)
+//	l.Debugw("request handled", logger.Since(start))
+func Since(start time.Time) zap.Field {
+	return zap.Duration(ElapsedKey, time.Since(start))
+}
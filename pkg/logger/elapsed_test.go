@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSince(t *testing.T) { //nolint:paralleltest // Replaces the global clock
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+	start := time.Now()
+	time.Sleep(20 * time.Millisecond)
+
+	// A wall clock jumping back an hour does not affect the elapsed time.
+	clock := newFakeClock()
+	clock.now = start.Add(-time.Hour).Round(0)
+	defer SetClock(clock)()
+
+	l.Debugw("first", Since(start))
+	l.Debugw("second", Since(start))
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	first := entries[0].ContextMap()[ElapsedKey].(time.Duration)
+	second := entries[1].ContextMap()[ElapsedKey].(time.Duration)
+	assert.GreaterOrEqual(t, first, 20*time.Millisecond)
+	assert.Less(t, first, 5*time.Second)
+	assert.GreaterOrEqual(t, second, first, "elapsed times are monotonic")
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ElevateFor lowers the minimum level of the component logged through the logger with the given
+// name, as returned by Named, and of its descendants, to level for duration d, after which the
+// configured level applies again. This lets operators temporarily turn up the verbosity of a single
+// component, e.g. to debug, during incident response. Overlapping calls for the same component are
+// combined: the most verbose level in effect applies until every elevation has expired.
+// Only loggers built by this package, through Initialize or NewLogger, honour elevated levels.
+func (l *Logger) ElevateFor(name string, level zapcore.Level, d time.Duration) {
+	id := elevatedLevels.add(name, level)
+	l.Infow("elevated log level", "component", name, "level", level, "duration", d)
+	time.AfterFunc(d, func() {
+		elevatedLevels.remove(name, id)
+		l.Infow("restored log level", "component", name)
+	})
+}
+
+// componentLevels tracks the components whose level has been elevated by ElevateFor.
+type componentLevels struct {
+	mu sync.RWMutex
+	// elevations maps component names to the levels of their active elevations, by ID.
+	elevations map[string]map[uint64]zapcore.Level
+	nextID     uint64
+}
+
+// elevatedLevels holds the elevations applied by the loggers built by this package.
+var elevatedLevels = &componentLevels{elevations: map[string]map[uint64]zapcore.Level{}}
+
+func (c *componentLevels) add(name string, level zapcore.Level) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.elevations[name] == nil {
+		c.elevations[name] = map[uint64]zapcore.Level{}
+	}
+	c.nextID++
+	c.elevations[name][c.nextID] = level
+	return c.nextID
+}
+
+func (c *componentLevels) remove(name string, id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.elevations[name], id)
+	if len(c.elevations[name]) == 0 {
+		delete(c.elevations, name)
+	}
+}
+
+// lowest returns the most verbose level any component has been elevated to.
+func (c *componentLevels) lowest() (zapcore.Level, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lowestLocked(func(string) bool { return true })
+}
+
+// levelFor returns the most verbose level the logger with the given name has been elevated to,
+// either directly or through one of its ancestors.
+func (c *componentLevels) levelFor(loggerName string) (zapcore.Level, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lowestLocked(func(name string) bool {
+		return loggerName == name || strings.HasPrefix(loggerName, name+".")
+	})
+}
+
+func (c *componentLevels) lowestLocked(matches func(name string) bool) (zapcore.Level, bool) {
+	lowest, found := zapcore.InvalidLevel, false
+	for name, levels := range c.elevations {
+		if !matches(name) {
+			continue
+		}
+		for _, level := range levels {
+			if !found || level < lowest {
+				lowest, found = level, true
+			}
+		}
+	}
+	return lowest, found
+}
+
+// componentLevelCore enables the entries of elevated components which the wrapped core would
+// otherwise drop. Like debugTraceCore, it writes them to the wrapped core directly.
+type componentLevelCore struct {
+	zapcore.Core
+	levels *componentLevels
+}
+
+func newComponentLevelCore(core zapcore.Core, levels *componentLevels) zapcore.Core {
+	return &componentLevelCore{Core: core, levels: levels}
+}
+
+func (c *componentLevelCore) Enabled(level zapcore.Level) bool {
+	if c.Core.Enabled(level) {
+		return true
+	}
+	lowest, ok := c.levels.lowest()
+	return ok && level >= lowest
+}
+
+func (c *componentLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &componentLevelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *componentLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(ent.Level) {
+		if level, ok := c.levels.levelFor(ent.LoggerName); ok && ent.Level >= level {
+			return ce.AddCore(ent, c.Core)
+		}
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newElevatableLogger returns a Logger at info level which honours ElevateFor, recording its
+// entries in memory.
+func newElevatableLogger() (*Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	return &Logger{SugaredLogger: zap.New(newComponentLevelCore(core, elevatedLevels)).Sugar()}, logs
+}
+
+func TestElevateFor(t *testing.T) {
+	t.Parallel()
+	l, logs := newElevatableLogger()
+	component := l.Named("elevate-test")
+	child := component.Named("child")
+	other := l.Named("elevate-test-other")
+
+	component.Debug("before")
+	l.ElevateFor("elevate-test", zapcore.DebugLevel, 200*time.Millisecond)
+	component.Debug("during")
+	child.Debug("child during")
+	other.Debug("other during")
+
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("restored log level").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	component.Debug("after")
+	component.Info("info after")
+
+	assert.Zero(t, logs.FilterMessage("before").Len())
+	assert.Equal(t, 1, logs.FilterMessage("during").Len())
+	assert.Equal(t, 1, logs.FilterMessage("child during").Len())
+	assert.Zero(t, logs.FilterMessage("other during").Len())
+	assert.Zero(t, logs.FilterMessage("after").Len())
+	assert.Equal(t, 1, logs.FilterMessage("info after").Len())
+}
+
+func TestElevateForOverlapping(t *testing.T) {
+	t.Parallel()
+	l, logs := newElevatableLogger()
+	component := l.Named("elevate-overlap-test")
+
+	l.ElevateFor("elevate-overlap-test", zapcore.DebugLevel, 50*time.Millisecond)
+	l.ElevateFor("elevate-overlap-test", zapcore.DebugLevel, time.Second)
+
+	// The first elevation expiring leaves the second in effect.
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("restored log level").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	component.Debug("between")
+	assert.Equal(t, 1, logs.FilterMessage("between").Len())
+
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("restored log level").Len() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	component.Debug("after")
+	assert.Zero(t, logs.FilterMessage("after").Len())
+}
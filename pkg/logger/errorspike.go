@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// ErrorSpikeThresholdEnvVar is the environment variable holding the number of error entries
+	// within ErrorSpikeWindowEnvVar which enables debug logging and stack traces for the cooldown
+	// set by ErrorSpikeCooldownEnvVar. Zero or unset disables adaptive logging.
+	ErrorSpikeThresholdEnvVar = "LOG_ERROR_SPIKE_THRESHOLD"
+	// ErrorSpikeWindowEnvVar is the environment variable holding the sliding window, e.g. "30s",
+	// over which error entries are counted. Defaults to DefaultErrorSpikeWindow.
+	ErrorSpikeWindowEnvVar = "LOG_ERROR_SPIKE_WINDOW"
+	// ErrorSpikeCooldownEnvVar is the environment variable holding how long, e.g. "10m", debug
+	// logging stays enabled after the last spike. Defaults to DefaultErrorSpikeCooldown.
+	ErrorSpikeCooldownEnvVar = "LOG_ERROR_SPIKE_COOLDOWN"
+
+	// DefaultErrorSpikeWindow is the default window over which error entries are counted.
+	DefaultErrorSpikeWindow = time.Minute
+	// DefaultErrorSpikeCooldown is the default time debug logging stays enabled after a spike.
+	DefaultErrorSpikeCooldown = 5 * time.Minute
+)
+
+// errorSpikeCore enables entries at every level, and adds stack traces to those at warn level and
+// above, for a cooldown period once the number of error entries within a sliding window reaches a
+// threshold. While elevated, entries are written to the wrapped core directly, bypassing its level
+// checks and sampling, as debugTraceCore does.
+type errorSpikeCore struct {
+	zapcore.Core
+	state *errorSpikeState
+}
+
+// errorSpikeState tracks recent errors across a logger and all loggers derived from it.
+type errorSpikeState struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	mu            sync.Mutex
+	errors        []time.Time
+	elevatedUntil time.Time
+}
+
+func newErrorSpikeCore(core zapcore.Core, threshold int, window, cooldown time.Duration) zapcore.Core {
+	return &errorSpikeCore{
+		Core:  core,
+		state: &errorSpikeState{threshold: threshold, window: window, cooldown: cooldown},
+	}
+}
+
+func (c *errorSpikeCore) Enabled(level zapcore.Level) bool {
+	return c.Core.Enabled(level) || (level >= zapcore.DebugLevel && c.state.elevated(now()))
+}
+
+func (c *errorSpikeCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorSpikeCore{Core: c.Core.With(fields), state: c.state}
+}
+
+func (c *errorSpikeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level >= zapcore.ErrorLevel && c.state.recordError(ent.Time) {
+		c.notify(ent.Time)
+	}
+	if ent.Level >= zapcore.DebugLevel && c.state.elevated(ent.Time) {
+		return ce.AddCore(ent, c)
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *errorSpikeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level >= zapcore.WarnLevel && ent.Stack == "" {
+		ent.Stack = zap.Stack("").String
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// notify logs that a spike has enabled debug logging.
+func (c *errorSpikeCore) notify(now time.Time) {
+	if !c.Core.Enabled(zapcore.WarnLevel) {
+		return
+	}
+	ent := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    now,
+		Message: "error rate spike detected, enabling debug logging",
+	}
+	_ = c.Core.Write(ent, []zapcore.Field{
+		zap.Int("threshold", c.state.threshold),
+		zap.Duration("window", c.state.window),
+		zap.Duration("cooldown", c.state.cooldown),
+	})
+}
+
+// recordError records an error entry logged at the given time, and reports whether it starts a spike.
+// Errors logged while elevated extend the cooldown once they reach the threshold again.
+func (s *errorSpikeState) recordError(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := now.Add(-s.window)
+	recent := s.errors[:0]
+	for _, t := range s.errors {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	s.errors = append(recent, now)
+	if len(s.errors) < s.threshold {
+		return false
+	}
+
+	started := !now.Before(s.elevatedUntil)
+	s.elevatedUntil = now.Add(s.cooldown)
+	s.errors = s.errors[:0]
+	return started
+}
+
+func (s *errorSpikeState) elevated(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Before(s.elevatedUntil)
+}
+
+// errorSpikeSettings returns the adaptive logging settings configured in the environment.
+// A zero threshold means adaptive logging is disabled.
+func errorSpikeSettings() (threshold int, window, cooldown time.Duration) {
+	threshold, err := strconv.Atoi(os.Getenv(ErrorSpikeThresholdEnvVar))
+	if err != nil || threshold < 0 {
+		threshold = 0
+	}
+	window = durationFromEnv(ErrorSpikeWindowEnvVar, DefaultErrorSpikeWindow)
+	cooldown = durationFromEnv(ErrorSpikeCooldownEnvVar, DefaultErrorSpikeCooldown)
+	return threshold, window, cooldown
+}
+
+// durationFromEnv returns the positive duration held by the environment variable, or defaultValue
+// if it is unset or invalid.
+func durationFromEnv(envVar string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(envVar))
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
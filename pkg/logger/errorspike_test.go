@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErrorSpikeCore(t *testing.T) {
+	t.Parallel()
+	const cooldown = 200 * time.Millisecond
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(newErrorSpikeCore(core, 3, time.Minute, cooldown))
+
+	log.Debug("before spike")
+	log.Warn("warning before spike")
+	log.Error("first failure")
+	log.Error("second failure")
+	assert.False(t, log.Core().Enabled(zapcore.DebugLevel))
+
+	log.Error("third failure")
+	assert.True(t, log.Core().Enabled(zapcore.DebugLevel))
+	log.Debug("during spike")
+	log.Warn("warning during spike")
+
+	require.Eventually(t, func() bool {
+		return !log.Core().Enabled(zapcore.DebugLevel)
+	}, 5*time.Second, 10*time.Millisecond)
+	log.Debug("after cooldown")
+
+	assert.Zero(t, logs.FilterMessage("before spike").Len())
+	assert.Equal(t, 1, logs.FilterMessage("error rate spike detected, enabling debug logging").Len())
+	assert.Equal(t, 1, logs.FilterMessage("during spike").Len())
+	assert.Zero(t, logs.FilterMessage("after cooldown").Len())
+
+	assert.Empty(t, logs.FilterMessage("warning before spike").All()[0].Stack)
+	assert.NotEmpty(t, logs.FilterMessage("warning during spike").All()[0].Stack)
+}
+
+func TestErrorSpikeCoreWindow(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.InfoLevel)
+	c := newErrorSpikeCore(core, 2, time.Minute, time.Minute)
+
+	// Errors further apart than the window do not add up to a spike.
+	start := time.Now()
+	for i := range 3 {
+		ent := zapcore.Entry{Level: zapcore.ErrorLevel, Time: start.Add(time.Duration(i) * 2 * time.Minute), Message: "failure"}
+		if ce := c.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, 3, logs.FilterMessage("failure").Len())
+	assert.Zero(t, logs.FilterMessage("error rate spike detected, enabling debug logging").Len())
+}
+
+func TestErrorSpikeSettings(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(ErrorSpikeThresholdEnvVar, "")
+	t.Setenv(ErrorSpikeWindowEnvVar, "")
+	t.Setenv(ErrorSpikeCooldownEnvVar, "")
+	threshold, window, cooldown := errorSpikeSettings()
+	assert.Zero(t, threshold)
+	assert.Equal(t, DefaultErrorSpikeWindow, window)
+	assert.Equal(t, DefaultErrorSpikeCooldown, cooldown)
+
+	t.Setenv(ErrorSpikeThresholdEnvVar, "10")
+	t.Setenv(ErrorSpikeWindowEnvVar, "30s")
+	t.Setenv(ErrorSpikeCooldownEnvVar, "never")
+	threshold, window, cooldown = errorSpikeSettings()
+	assert.Equal(t, 10, threshold)
+	assert.Equal(t, 30*time.Second, window)
+	assert.Equal(t, DefaultErrorSpikeCooldown, cooldown)
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// WindowsEventLogEnvVar is the environment variable which, when set to true, sends log entries
+	// to the Windows Event Log instead of stdout or stderr. It is rejected on other platforms.
+	WindowsEventLogEnvVar = "LOG_WINDOWS_EVENTLOG"
+
+	eventLogSource  = "ToolHive"
+	eventLogEventID = 1
+)
+
+// eventLogWriter writes messages to the Windows Event Log with the given event type.
+// It is implemented by *eventlog.Log.
+type eventLogWriter interface {
+	Info(eid uint32, msg string) error
+	Warning(eid uint32, msg string) error
+	Error(eid uint32, msg string) error
+	Close() error
+}
+
+// eventLogCore writes encoded entries to the Windows Event Log, mapping debug and info entries
+// to Information events, warn entries to Warning events, and more severe entries to Error events.
+type eventLogCore struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	writer eventLogWriter
+}
+
+func newEventLogCore(enc zapcore.Encoder, enabler zapcore.LevelEnabler, writer eventLogWriter) zapcore.Core {
+	return &eventLogCore{LevelEnabler: enabler, enc: enc, writer: writer}
+}
+
+func (c *eventLogCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	return &eventLogCore{LevelEnabler: c.LevelEnabler, enc: enc, writer: c.writer}
+}
+
+func (c *eventLogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *eventLogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	msg := strings.TrimRight(buf.String(), "\r\n")
+	buf.Free()
+
+	switch {
+	case ent.Level <= zapcore.InfoLevel:
+		err = c.writer.Info(eventLogEventID, msg)
+	case ent.Level == zapcore.WarnLevel:
+		err = c.writer.Warning(eventLogEventID, msg)
+	default:
+		err = c.writer.Error(eventLogEventID, msg)
+	}
+	if err == nil {
+		emittedEntries.Add(1)
+	}
+	return err
+}
+
+// Sync is a no-op, as the Event Log writes every entry synchronously.
+func (*eventLogCore) Sync() error {
+	return nil
+}
+
+// windowsEventLog reports whether the Windows Event Log has been requested through WindowsEventLogEnvVar.
+func windowsEventLog() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(WindowsEventLogEnvVar))
+	return err == nil && enabled
+}
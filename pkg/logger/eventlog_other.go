@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"fmt"
+)
+
+// openEventLog always fails, as the Windows Event Log only exists on Windows.
+func openEventLog() (eventLogWriter, error) {
+	return nil, fmt.Errorf("%s is only supported on Windows", WindowsEventLogEnvVar)
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowsEventLogRejected(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+	t.Setenv(WindowsEventLogEnvVar, "true")
+
+	_, err := build()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_WINDOWS_EVENTLOG is only supported on Windows")
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type eventLogRecord struct {
+	eventType string
+	msg       string
+}
+
+// fakeEventLog records the events written to it.
+type fakeEventLog struct {
+	records []eventLogRecord
+}
+
+func (f *fakeEventLog) Info(_ uint32, msg string) error    { return f.record("info", msg) }
+func (f *fakeEventLog) Warning(_ uint32, msg string) error { return f.record("warning", msg) }
+func (f *fakeEventLog) Error(_ uint32, msg string) error   { return f.record("error", msg) }
+func (*fakeEventLog) Close() error                         { return nil }
+
+func (f *fakeEventLog) record(eventType, msg string) error {
+	f.records = append(f.records, eventLogRecord{eventType: eventType, msg: msg})
+	return nil
+}
+
+func TestEventLogCore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level     zapcore.Level
+		eventType string
+	}{
+		{zapcore.DebugLevel, "info"},
+		{zapcore.InfoLevel, "info"},
+		{zapcore.WarnLevel, "warning"},
+		{zapcore.ErrorLevel, "error"},
+		{zapcore.DPanicLevel, "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			t.Parallel()
+			writer := &fakeEventLog{}
+			enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+			log := zap.New(newEventLogCore(enc, zapcore.DebugLevel, writer))
+
+			log.With(zap.String("component", "proxy")).Log(tt.level, "event", zap.Int("attempt", 2))
+
+			require.Len(t, writer.records, 1)
+			assert.Equal(t, tt.eventType, writer.records[0].eventType)
+			assert.Contains(t, writer.records[0].msg, `"msg":"event"`)
+			assert.Contains(t, writer.records[0].msg, `"component":"proxy"`)
+			assert.Contains(t, writer.records[0].msg, `"attempt":2`)
+			assert.NotContains(t, writer.records[0].msg, "\n")
+		})
+	}
+}
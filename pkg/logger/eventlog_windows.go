@@ -0,0 +1,21 @@
+//go:build windows
+// +build windows
+
+package logger
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// openEventLog opens the Windows Event Log for the ToolHive event source.
+// The source does not need to be registered, although unregistered sources
+// are shown without a message description in the Event Viewer.
+func openEventLog() (eventLogWriter, error) {
+	log, err := eventlog.Open(eventLogSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Windows Event Log: %w", err)
+	}
+	return log, nil
+}
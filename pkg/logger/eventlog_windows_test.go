@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWindowsEventLog(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+	t.Setenv(WindowsEventLogEnvVar, "true")
+
+	log, err := build()
+	require.NoError(t, err)
+
+	before := Stats().Emitted
+	log.Info("information event", zap.String("test", t.Name()))
+	log.Warn("warning event", zap.String("test", t.Name()))
+	log.Error("error event", zap.String("test", t.Name()))
+	require.Equal(t, before+3, Stats().Emitted)
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap"
+)
+
+// InvalidJSONPrefix starts the string logged by RawJSON in place of bytes which are not valid JSON.
+const InvalidJSONPrefix = "invalid JSON: "
+
+// RawJSON returns a field which embeds raw as a nested JSON value, rather than as an escaped
+// string as happens when a json.RawMessage is logged directly. If raw is not valid JSON, it
+// is logged as a string starting with InvalidJSONPrefix instead, so that the entry stays valid.
+func RawJSON(key string, raw json.RawMessage) zap.Field {
+	if !json.Valid(raw) {
+		return zap.String(key, InvalidJSONPrefix+string(raw))
+	}
+	// The reflected encoder marshals the json.RawMessage as is.
+	return zap.Reflect(key, raw)
+}
+
+// MultiError returns a field which logs the errors aggregated by err, such as those joined by
+// errors.Join, as an "errors" array holding the message of each. Nested aggregates are flattened,
+// and an error which aggregates nothing is logged as an array of its own message. A nil err is skipped.
+func MultiError(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Strings("errors", errorMessages(err))
+}
+
+func errorMessages(err error) []string {
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []string{err.Error()}
+	}
+	var messages []string
+	for _, e := range multi.Unwrap() {
+		if e != nil {
+			messages = append(messages, errorMessages(e)...)
+		}
+	}
+	return messages
+}
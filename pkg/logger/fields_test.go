@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRawJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		raw      json.RawMessage
+		expected any
+	}{
+		{
+			name:     "Object",
+			raw:      json.RawMessage(`{"jsonrpc": "2.0", "params": {"name": "fetch"}}`),
+			expected: map[string]any{"jsonrpc": "2.0", "params": map[string]any{"name": "fetch"}},
+		},
+		{
+			name:     "Array",
+			raw:      json.RawMessage(`[1, "two", null]`),
+			expected: []any{float64(1), "two", nil},
+		},
+		{
+			name:     "Invalid",
+			raw:      json.RawMessage(`{"jsonrpc": "2.0",`),
+			expected: InvalidJSONPrefix + `{"jsonrpc": "2.0",`,
+		},
+		{
+			name:     "Empty",
+			raw:      nil,
+			expected: InvalidJSONPrefix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+			log := zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.DebugLevel))
+
+			log.Info("message", RawJSON("payload", tt.raw))
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), "entry must be valid JSON: %s", buf.String())
+			assert.Equal(t, tt.expected, entry["payload"])
+		})
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected any
+	}{
+		{
+			name: "Joined",
+			err: errors.Join(
+				errors.New("servers[0].image: must not be empty"),
+				fmt.Errorf("otel: %w", errors.New("invalid endpoint")),
+				errors.New("registry_url: invalid URL"),
+			),
+			expected: []any{"servers[0].image: must not be empty", "otel: invalid endpoint", "registry_url: invalid URL"},
+		},
+		{
+			name:     "Nested",
+			err:      errors.Join(errors.Join(errors.New("first"), errors.New("second")), errors.New("third")),
+			expected: []any{"first", "second", "third"},
+		},
+		{
+			name:     "Single",
+			err:      errors.New("only"),
+			expected: []any{"only"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+			log := zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.DebugLevel))
+
+			log.Error("validation failed", MultiError(tt.err))
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.expected, entry["errors"])
+		})
+	}
+
+	t.Run("Nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, zapcore.SkipType, MultiError(nil).Type)
+	})
+}
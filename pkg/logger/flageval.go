@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// FlagEvalSampleEnvVar is the environment variable holding the sampling rate of the entries logged by
+// LogFlagEval. With a rate of N, only the first evaluation of each flag with each result, and then every
+// Nth one, is logged, and the entry carries the number of evaluations it represents. Every evaluation
+// is logged when unset.
+const FlagEvalSampleEnvVar = "LOG_FLAG_EVAL_SAMPLE"
+
+// flagEvals counts the evaluations of each flag with each result, for sampling.
+var flagEvals = &flagEvalCounter{counts: map[flagEval]uint64{}}
+
+// LogFlagEval logs the evaluation of a feature flag at debug level, with the flag name, whether it
+// is enabled, and the reason for the result, e.g. "env" or "default", for experiment analysis.
+// Entries are sampled as configured by FlagEvalSampleEnvVar.
+func LogFlagEval(l *Logger, flag string, enabled bool, reason string) {
+	fields := []any{zap.String("flag", flag), zap.Bool("enabled", enabled), zap.String("reason", reason)}
+	if rate := flagEvalSampleRate(); rate > 1 {
+		count := flagEvals.add(flagEval{flag: flag, enabled: enabled})
+		if count%rate != 1 {
+			return
+		}
+		// The first evaluation is logged alone, and every later entry stands for the rate evaluations since the last.
+		fields = append(fields, zap.Uint64("evaluations", min(count, rate)))
+	}
+	l.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar().Debugw("feature flag evaluated", fields...)
+}
+
+// flagEval identifies the evaluations of a flag with a result.
+type flagEval struct {
+	flag    string
+	enabled bool
+}
+
+// flagEvalCounter counts evaluations of flags.
+type flagEvalCounter struct {
+	mu     sync.Mutex
+	counts map[flagEval]uint64
+}
+
+// add counts an evaluation, and returns the number of evaluations counted so far.
+func (c *flagEvalCounter) add(eval flagEval) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[eval]++
+	return c.counts[eval]
+}
+
+// flagEvalSampleRate returns the sampling rate set by FlagEvalSampleEnvVar, or 0 if every evaluation is logged.
+func flagEvalSampleRate() uint64 {
+	rate, err := strconv.ParseUint(os.Getenv(FlagEvalSampleEnvVar), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return rate
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogFlagEval(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(FlagEvalSampleEnvVar, "")
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	LogFlagEval(l, "beta", true, "env")
+	LogFlagEval(l, "beta", true, "env")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "feature flag evaluated", entries[0].Message)
+	assert.Equal(t, map[string]any{"flag": "beta", "enabled": true, "reason": "env"}, entries[0].ContextMap())
+}
+
+func TestLogFlagEvalSampled(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(FlagEvalSampleEnvVar, "10")
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+	// Counts are kept for the process, so the flag is unique to each run of the test.
+	flag := "sampled-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	for range 25 {
+		LogFlagEval(l, flag, true, "config")
+	}
+	LogFlagEval(l, flag, false, "default")
+
+	entries := logs.All()
+	require.Len(t, entries, 4, "the first and every tenth evaluation with each result is logged")
+	var evaluations []any
+	for _, entry := range entries {
+		assert.Equal(t, flag, entry.ContextMap()["flag"])
+		evaluations = append(evaluations, entry.ContextMap()["evaluations"])
+	}
+	assert.Equal(t, []any{uint64(1), uint64(10), uint64(10), uint64(1)}, evaluations)
+	assert.Equal(t, false, entries[3].ContextMap()["enabled"])
+	assert.Equal(t, "default", entries[3].ContextMap()["reason"])
+}
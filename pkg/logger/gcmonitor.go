@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GCThresholdEnvVar is the environment variable holding the GC pause, in milliseconds, above which
+// the monitor returned by NewGCMonitorFromEnv logs a warn entry. Zero or unset disables the monitor.
+const GCThresholdEnvVar = "LOG_GC_THRESHOLD_MS"
+
+// GCMonitor logs a warn entry for every garbage collection whose stop-the-world pause exceeds a
+// threshold, with the pause in milliseconds as pause_ms and the heap allocation after it as
+// heap_alloc_bytes, so that latency spikes can be correlated with garbage collection. It is
+// notified of each completed collection, and reads the pauses since the last one it saw.
+// A nil *GCMonitor is a valid monitor which does nothing.
+type GCMonitor struct {
+	logger    *Logger
+	threshold time.Duration
+
+	mu sync.Mutex
+	// stopped is closed when the monitor is stopped, and nil while it is not running.
+	stopped chan struct{}
+	// lastGC is the number of the last collection whose pause has been checked.
+	lastGC uint32
+}
+
+// NewGCMonitor creates a GCMonitor logging pauses longer than threshold to l. It must be started with Start.
+func NewGCMonitor(l *Logger, threshold time.Duration) *GCMonitor {
+	return &GCMonitor{logger: l, threshold: threshold}
+}
+
+// NewGCMonitorFromEnv creates a GCMonitor with the threshold held by GCThresholdEnvVar, or returns
+// nil if it is unset or invalid.
+func NewGCMonitorFromEnv(l *Logger) *GCMonitor {
+	threshold, err := strconv.Atoi(os.Getenv(GCThresholdEnvVar))
+	if err != nil || threshold <= 0 {
+		return nil
+	}
+	return NewGCMonitor(l, time.Duration(threshold)*time.Millisecond)
+}
+
+// Start starts monitoring the collections completed from now on. Starting a running monitor does nothing.
+func (m *GCMonitor) Start() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped != nil {
+		return
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	m.lastGC = stats.NumGC
+	m.stopped = make(chan struct{})
+
+	collected := make(chan struct{}, 1)
+	notifyGC(collected, m.stopped)
+	go m.run(collected, m.stopped)
+}
+
+// Stop stops monitoring collections. The monitor can be started again. Stopping a monitor which is
+// not running does nothing.
+func (m *GCMonitor) Stop() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped != nil {
+		close(m.stopped)
+		m.stopped = nil
+	}
+}
+
+func (m *GCMonitor) run(collected <-chan struct{}, stopped <-chan struct{}) {
+	for {
+		select {
+		case <-stopped:
+			return
+		case <-collected:
+			m.check(stopped)
+		}
+	}
+}
+
+// check logs the pauses longer than the threshold of the collections completed since the last check.
+func (m *GCMonitor) check(stopped <-chan struct{}) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// The monitor may have been stopped, or stopped and started again, since the collection.
+	if m.stopped != stopped {
+		return
+	}
+	// Only the pauses of the last len(stats.PauseNs) collections are kept, the pause of collection
+	// n being at index (n-1) % len(stats.PauseNs).
+	kept := uint32(len(stats.PauseNs))
+	first := m.lastGC + 1
+	if stats.NumGC > kept {
+		first = max(first, stats.NumGC-kept+1)
+	}
+	for gc := first; gc <= stats.NumGC; gc++ {
+		pause := time.Duration(stats.PauseNs[(gc-1)%kept]) //nolint:gosec // Pauses fit in a Duration
+		if pause > m.threshold {
+			m.logger.Desugar().Warn("long GC pause",
+				zap.Float64("pause_ms", float64(pause)/float64(time.Millisecond)),
+				zap.Uint64("heap_alloc_bytes", stats.HeapAlloc),
+				zap.Uint32("gc", gc))
+		}
+	}
+	m.lastGC = stats.NumGC
+}
+
+// gcSentinel is an object whose finalizer runs after each collection. It holds a pointer so that it
+// is not allocated by the tiny allocator, whose objects may never be finalized.
+type gcSentinel struct {
+	_ *byte
+}
+
+// notifyGC sends to collected, without blocking, after the next collection and every collection
+// after it, until stopped is closed.
+func notifyGC(collected chan<- struct{}, stopped <-chan struct{}) {
+	runtime.SetFinalizer(&gcSentinel{}, func(*gcSentinel) {
+		select {
+		case <-stopped:
+			return
+		default:
+		}
+		select {
+		case collected <- struct{}{}:
+		default:
+		}
+		notifyGC(collected, stopped)
+	})
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGCMonitor(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+	monitor := NewGCMonitor(l, time.Nanosecond)
+	monitor.Start()
+	monitor.Start()
+	defer monitor.Stop()
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+		return logs.FilterMessage("long GC pause").Len() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	entry := logs.FilterMessage("long GC pause").All()[0]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	fields := entry.ContextMap()
+	assert.Greater(t, fields["pause_ms"], float64(0))
+	assert.Greater(t, fields["heap_alloc_bytes"], uint64(0))
+	assert.Greater(t, fields["gc"], uint32(0))
+}
+
+func TestGCMonitorStop(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+	monitor := NewGCMonitor(l, time.Nanosecond)
+	monitor.Start()
+	monitor.Stop()
+	monitor.Stop()
+
+	runtime.GC()
+	runtime.GC()
+	assert.Zero(t, logs.Len(), "a stopped monitor does not log")
+
+	monitor.Start()
+	defer monitor.Stop()
+	require.Eventually(t, func() bool {
+		runtime.GC()
+		return logs.Len() > 0
+	}, 5*time.Second, 10*time.Millisecond, "a stopped monitor can be started again")
+}
+
+func TestNewGCMonitorFromEnv(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	l, _ := newObservedLogger(zapcore.DebugLevel)
+
+	t.Setenv(GCThresholdEnvVar, "")
+	monitor := NewGCMonitorFromEnv(l)
+	assert.Nil(t, monitor)
+	monitor.Start()
+	monitor.Stop()
+
+	t.Setenv(GCThresholdEnvVar, "invalid")
+	assert.Nil(t, NewGCMonitorFromEnv(l))
+
+	t.Setenv(GCThresholdEnvVar, "50")
+	monitor = NewGCMonitorFromEnv(l)
+	require.NotNil(t, monitor)
+	assert.Equal(t, 50*time.Millisecond, monitor.threshold)
+}
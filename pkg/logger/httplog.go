@@ -0,0 +1,247 @@
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// HTTPBodyEnvVar is the environment variable which, when set to true, makes HTTPMiddleware
+	// log request and response bodies along with each request.
+	HTTPBodyEnvVar = "LOG_HTTP_BODY"
+	// HTTPBodyMaxEnvVar is the environment variable holding the maximum number of bytes of each
+	// body which are logged. Longer bodies are truncated. Defaults to DefaultHTTPBodyMax.
+	HTTPBodyMaxEnvVar = "LOG_HTTP_BODY_MAX"
+	// HTTPBodyRedactEnvVar is the environment variable holding a comma-separated list of JSON
+	// fields redacted from logged bodies, in addition to the default sensitive fields.
+	HTTPBodyRedactEnvVar = "LOG_HTTP_BODY_REDACT"
+
+	// DefaultHTTPBodyMax is the default maximum number of bytes of each body which are logged.
+	DefaultHTTPBodyMax = 4096
+)
+
+// defaultSensitiveBodyFields are the JSON fields always redacted from logged bodies, matched
+// case-insensitively at any depth. "value" is included as it carries secrets in the secrets API.
+var defaultSensitiveBodyFields = []string{
+	"password", "secret", "token", "api_key", "apikey", "access_token", "refresh_token",
+	"client_secret", "authorization", "credentials", "private_key", "value",
+}
+
+// HTTPMiddleware returns middleware which logs every request at debug level with its method,
+// path, status, response size and duration, using the Logger for the request's context.
+// When enabled through HTTPBodyEnvVar, request and response bodies are logged as well, up to
+// the size set by HTTPBodyMaxEnvVar, with sensitive JSON fields redacted.
+func HTTPMiddleware(l *Logger) func(http.Handler) http.Handler {
+	return httpMiddleware(l, nil)
+}
+
+// ChiHTTPMiddleware returns middleware which logs every request as HTTPMiddleware does, adding
+// the pattern of the chi route which matched it as the "route" field, e.g. "/servers/{name}",
+// so that requests can be grouped by route without a distinct value for every resource.
+// It must be used with a chi router, and requests which matched no route are logged without it.
+func ChiHTTPMiddleware(l *Logger) func(http.Handler) http.Handler {
+	return httpMiddleware(l, func(r *http.Request) string {
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			return rctx.RoutePattern()
+		}
+		return ""
+	})
+}
+
+// httpMiddleware returns the middleware behind HTTPMiddleware. If routePattern is not nil, it is
+// called once the request has been served to obtain the pattern of the route which matched it.
+func httpMiddleware(l *Logger, routePattern func(*http.Request) string) func(http.Handler) http.Handler {
+	opts := httpBodyOptionsFromEnv()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := now()
+
+			var reqBody *bodyCapture
+			if opts.enabled && r.Body != nil {
+				reqBody = &bodyCapture{max: opts.max}
+				r.Body = &capturingReadCloser{ReadCloser: r.Body, capture: reqBody}
+			}
+			rw := &loggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			if opts.enabled {
+				rw.body = &bodyCapture{max: opts.max}
+			}
+
+			next.ServeHTTP(rw, r)
+
+			fields := []any{
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", rw.statusCode),
+				zap.Int64("bytes", rw.bytesWritten),
+				zap.Duration("duration", since(start)),
+			}
+			if routePattern != nil {
+				if route := routePattern(r); route != "" {
+					fields = append(fields, zap.String("route", route))
+				}
+			}
+			if reqBody != nil {
+				fields = append(fields, opts.bodyFields("request_body", reqBody)...)
+			}
+			if rw.body != nil {
+				fields = append(fields, opts.bodyFields("response_body", rw.body)...)
+			}
+			l.ForContext(r.Context()).Debugw("http request", fields...)
+		})
+	}
+}
+
+// httpBodyOptions configure the logging of bodies by HTTPMiddleware.
+type httpBodyOptions struct {
+	enabled bool
+	max     int
+	fields  map[string]bool
+}
+
+func httpBodyOptionsFromEnv() httpBodyOptions {
+	opts := httpBodyOptions{max: DefaultHTTPBodyMax, fields: map[string]bool{}}
+	opts.enabled, _ = strconv.ParseBool(os.Getenv(HTTPBodyEnvVar))
+	if limit, err := strconv.Atoi(os.Getenv(HTTPBodyMaxEnvVar)); err == nil && limit >= 0 {
+		opts.max = limit
+	}
+	for _, field := range defaultSensitiveBodyFields {
+		opts.fields[field] = true
+	}
+	for _, field := range strings.Split(os.Getenv(HTTPBodyRedactEnvVar), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			opts.fields[strings.ToLower(field)] = true
+		}
+	}
+	return opts
+}
+
+// bodyFields returns the fields logging a captured body under the given key.
+func (o httpBodyOptions) bodyFields(key string, capture *bodyCapture) []any {
+	if capture.size == 0 {
+		return nil
+	}
+	body := o.redact(capture.data, capture.truncated())
+	fields := []any{zap.String(key, body), zap.Int64(key+"_size", capture.size)}
+	if capture.truncated() {
+		fields = append(fields, zap.Bool(key+"_truncated", true))
+	}
+	return fields
+}
+
+// redactedBodyValue replaces the values of sensitive fields in logged bodies.
+const redactedBodyValue = "[REDACTED]"
+
+// sensitiveFieldPattern matches a JSON member and its string or scalar value, including a
+// string value cut short by truncation. The member name is the first submatch.
+var sensitiveFieldPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s*:\s*("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+
+// redact replaces the values of sensitive fields in body. Complete JSON bodies are redacted
+// structurally; truncated or non-JSON bodies are redacted by matching members textually.
+func (o httpBodyOptions) redact(body []byte, truncated bool) string {
+	if !truncated {
+		var value any
+		if err := json.Unmarshal(body, &value); err == nil {
+			if redacted, err := json.Marshal(o.redactValue(value)); err == nil {
+				return string(redacted)
+			}
+		}
+	}
+
+	return sensitiveFieldPattern.ReplaceAllStringFunc(string(body), func(member string) string {
+		name := sensitiveFieldPattern.FindStringSubmatch(member)[1]
+		if !o.fields[strings.ToLower(name)] {
+			return member
+		}
+		return strconv.Quote(name) + ":" + strconv.Quote(redactedBodyValue)
+	})
+}
+
+func (o httpBodyOptions) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if o.fields[strings.ToLower(key)] {
+				v[key] = redactedBodyValue
+				continue
+			}
+			v[key] = o.redactValue(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = o.redactValue(item)
+		}
+	}
+	return value
+}
+
+// bodyCapture keeps the first max bytes of a body, and counts its total size.
+type bodyCapture struct {
+	max  int
+	data []byte
+	size int64
+}
+
+func (c *bodyCapture) write(p []byte) {
+	c.size += int64(len(p))
+	if remaining := c.max - len(c.data); remaining > 0 {
+		c.data = append(c.data, p[:min(remaining, len(p))]...)
+	}
+}
+
+func (c *bodyCapture) truncated() bool {
+	return c.size > int64(len(c.data))
+}
+
+// capturingReadCloser captures a request body as the handler reads it.
+type capturingReadCloser struct {
+	io.ReadCloser
+	capture *bodyCapture
+}
+
+func (r *capturingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.capture.write(p[:n])
+	return n, err
+}
+
+// loggingResponseWriter records the status and size of a response, and captures its body if enabled.
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	statusCode   int
+	bytesWritten int64
+	body         *bodyCapture
+}
+
+func (rw *loggingResponseWriter) WriteHeader(statusCode int) {
+	rw.statusCode = statusCode
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rw *loggingResponseWriter) Write(data []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(data)
+	rw.bytesWritten += int64(n)
+	if rw.body != nil {
+		rw.body.write(data[:n])
+	}
+	return n, err
+}
+
+// Flush flushes the underlying writer if it supports it, so that streamed responses keep working.
+func (rw *loggingResponseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer, for use by http.ResponseController.
+func (rw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
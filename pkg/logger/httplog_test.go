@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// echoHandler responds with the request body.
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(body)
+})
+
+func serveLogged(t *testing.T, body string) map[string]any {
+	t.Helper()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1beta/secrets/default/keys", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	HTTPMiddleware(l)(echoHandler).ServeHTTP(rec, req)
+	require.Equal(t, body, rec.Body.String())
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "http request", entries[0].Message)
+	return entries[0].ContextMap()
+}
+
+func TestHTTPMiddleware(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(HTTPBodyEnvVar, "false")
+
+	body := `{"key":"github","value":"ghp_secret"}`
+	fields := serveLogged(t, body)
+	assert.Equal(t, "POST", fields["method"])
+	assert.Equal(t, "/api/v1beta/secrets/default/keys", fields["path"])
+	assert.Equal(t, int64(http.StatusCreated), fields["status"])
+	assert.Equal(t, int64(len(body)), fields["bytes"])
+	assert.Contains(t, fields, "duration")
+	assert.NotContains(t, fields, "request_body")
+	assert.NotContains(t, fields, "response_body")
+}
+
+func TestHTTPMiddlewareBodies(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(HTTPBodyEnvVar, "true")
+	t.Setenv(HTTPBodyMaxEnvVar, "64")
+	t.Setenv(HTTPBodyRedactEnvVar, "pin, Session_ID")
+
+	t.Run("WithinCap", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		body := `{"name":"fetch","password":"hunter2","nested":[{"PIN":1234}]}`
+		fields := serveLogged(t, body)
+		assert.Equal(t, `{"name":"fetch","nested":[{"PIN":"[REDACTED]"}],"password":"[REDACTED]"}`, fields["request_body"])
+		assert.Equal(t, fields["request_body"], fields["response_body"])
+		assert.Equal(t, int64(len(body)), fields["request_body_size"])
+		assert.NotContains(t, fields, "request_body_truncated")
+	})
+
+	t.Run("BeyondCap", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		body := `{"session_id":"abc123","name":"` + strings.Repeat("x", 100) + `","token":"tok"}`
+		fields := serveLogged(t, body)
+		prefix := `{"session_id":"abc123"`
+		assert.Equal(t, `{"session_id":"[REDACTED]"`+body[len(prefix):64], fields["request_body"])
+		assert.Equal(t, int64(len(body)), fields["request_body_size"])
+		assert.Equal(t, true, fields["request_body_truncated"])
+		assert.Equal(t, true, fields["response_body_truncated"])
+	})
+
+	t.Run("SensitiveValueCutByCap", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		body := `{"name":"fetch","client_secret":"` + strings.Repeat("s", 100) + `"}`
+		fields := serveLogged(t, body)
+		assert.Equal(t, `{"name":"fetch","client_secret":"[REDACTED]"`, fields["request_body"])
+	})
+}
+
+func TestChiHTTPMiddleware(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(HTTPBodyEnvVar, "false")
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	servers := chi.NewRouter()
+	servers.Get("/{name}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r := chi.NewRouter()
+	r.Use(ChiHTTPMiddleware(l))
+	r.Mount("/api/v1beta/workloads", servers)
+
+	for _, path := range []string{"/api/v1beta/workloads/fetch", "/api/v1beta/workloads/github", "/unknown"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	for i, path := range []string{"/api/v1beta/workloads/fetch", "/api/v1beta/workloads/github"} {
+		fields := entries[i].ContextMap()
+		assert.Equal(t, path, fields["path"])
+		assert.Equal(t, "/api/v1beta/workloads/{name}", fields["route"])
+	}
+	assert.Equal(t, int64(http.StatusNotFound), entries[2].ContextMap()["status"])
+	assert.NotContains(t, entries[2].ContextMap(), "route")
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger is a structured logger which can be handed to components that need
+// their own derived logger, rather than relying on the package-level functions.
+type Logger struct {
+	*zap.SugaredLogger
+}
+
+// NewLogger creates a Logger configured from the environment in the same way as Initialize.
+// Any options given, such as hooks or cores wrapping the built core, are applied after those
+// derived from the environment.
+func NewLogger(opts ...zap.Option) *Logger {
+	return &Logger{SugaredLogger: zap.Must(build()).WithOptions(opts...).Sugar()}
+}
+
+// With returns a child Logger which adds the given key-value pairs to every entry.
+func (l *Logger) With(keysAndValues ...any) *Logger {
+	return &Logger{SugaredLogger: l.SugaredLogger.With(keysAndValues...)}
+}
+
+// LogFields logs a message at the given level with a pre-built slice of fields, which are
+// passed straight through to the underlying logger without converting them to key-value pairs.
+func (l *Logger) LogFields(level zapcore.Level, msg string, fields []zap.Field) {
+	logger := l.Desugar()
+	if !logger.Core().Enabled(level) {
+		return
+	}
+	logger.WithOptions(zap.AddCallerSkip(1)).Log(level, msg, fields...)
+}
+
+// Errorfs logs a message formatted with fmt.Sprintf at error level and returns it, so that the
+// message can be reused without formatting it twice, e.g. in an error returned to the user:
+//
+//	return errors.New(l.Errorfs("failed to start server %s: %v", name, err))
+//
+// The message is formatted and returned even if error level is disabled.
+func (l *Logger) Errorfs(format string, args ...any) string {
+	msg := fmt.Sprintf(format, args...)
+	l.Desugar().WithOptions(zap.AddCallerSkip(1)).Error(msg)
+	return msg
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newObservedLogger returns a Logger which records its entries in memory.
+func newObservedLogger(level zapcore.Level) (*Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return &Logger{SugaredLogger: zap.New(core).Sugar()}, logs
+}
+
+func TestNewLogger(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	l := NewLogger()
+	require.NotNil(t, l)
+	assert.True(t, l.Desugar().Core().Enabled(zapcore.InfoLevel))
+}
+
+func TestNewLoggerWithOptions(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	var hooked []string
+	hook := zap.Hooks(func(entry zapcore.Entry) error {
+		hooked = append(hooked, entry.Message)
+		return nil
+	})
+	observed, logs := observer.New(zapcore.DebugLevel)
+	tee := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, observed)
+	})
+
+	l := NewLogger(hook, tee)
+	l.Info("first")
+	l.Warn("second")
+
+	assert.Equal(t, []string{"first", "second"}, hooked)
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestLoggerWith(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	child := l.With("component", "test")
+	child.Infow("child message", "key", "value")
+	l.Info("parent message")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]any{"component": "test", "key": "value"}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
+}
+
+func TestLoggerLogFields(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range []zapcore.Level{zapcore.DebugLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+		t.Run(level.String(), func(t *testing.T) {
+			t.Parallel()
+			core, logs := observer.New(zapcore.DebugLevel)
+			l := &Logger{SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()}
+
+			var fields []zap.Field
+			for i, name := range []string{"alpha", "beta", "gamma"} {
+				fields = append(fields, zap.Int(name, i))
+			}
+			fields = append(fields, zap.String("level_name", level.String()))
+
+			l.LogFields(level, "dynamic fields", fields)
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, level, entries[0].Level)
+			assert.Equal(t, "dynamic fields", entries[0].Message)
+			assert.Equal(t, map[string]any{
+				"alpha":      int64(0),
+				"beta":       int64(1),
+				"gamma":      int64(2),
+				"level_name": level.String(),
+			}, entries[0].ContextMap())
+			assert.Contains(t, entries[0].Caller.File, "instance_test.go")
+		})
+	}
+}
+
+func TestLoggerLogFieldsDisabledLevel(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.InfoLevel)
+
+	l.LogFields(zapcore.DebugLevel, "hidden", []zap.Field{zap.String("key", "value")})
+
+	assert.Zero(t, logs.Len())
+}
+
+func TestLoggerErrorfs(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := &Logger{SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()}
+
+	msg := l.With("server", "fetch").Errorfs("failed to start server %s: %v", "fetch", errors.New("port in use"))
+
+	assert.Equal(t, "failed to start server fetch: port in use", msg)
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, msg, entries[0].Message)
+	assert.Equal(t, "fetch", entries[0].ContextMap()["server"])
+	assert.Contains(t, entries[0].Caller.File, "instance_test.go")
+}
@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// FormatEnvVar is the environment variable which, when set to journald, sends log entries to the
+	// systemd journal using its native protocol instead of stdout or stderr. Entries then carry their
+	// message as MESSAGE, their level as the syslog PRIORITY, and every field as a journal field named
+	// after its key, upper-cased with other characters than letters and digits replaced by "_".
+	// If the journal socket is unavailable, entries are written to stderr instead.
+	FormatEnvVar = "LOG_FORMAT"
+
+	formatJournald = "journald"
+
+	// journalSocketPath is the socket systemd-journald receives native protocol entries on.
+	journalSocketPath = "/run/systemd/journal/socket"
+
+	// journalFieldMaxLength is the maximum length of a journal field name.
+	journalFieldMaxLength = 64
+)
+
+// journaldFormat reports whether the systemd journal has been requested through FormatEnvVar.
+func journaldFormat() (bool, error) {
+	switch format := os.Getenv(FormatEnvVar); format {
+	case "":
+		return false, nil
+	case formatJournald:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported %s %q", FormatEnvVar, format)
+	}
+}
+
+// newJournaldCore returns a core sending entries to the journal socket at path, or writing them to
+// stderr with enc if the socket is unavailable.
+func newJournaldCore(path string, enc zapcore.Encoder, enabler zapcore.LevelEnabler) zapcore.Core {
+	conn, err := net.Dial("unixgram", path)
+	if err != nil {
+		return newOutputCore(enc, zapcore.Lock(os.Stderr), enabler)
+	}
+	return &journaldCore{LevelEnabler: enabler, conn: conn}
+}
+
+// journaldCore sends entries to the systemd journal as native protocol datagrams.
+type journaldCore struct {
+	zapcore.LevelEnabler
+	conn   net.Conn
+	fields []zapcore.Field
+}
+
+func (c *journaldCore) With(fields []zapcore.Field) zapcore.Core {
+	return &journaldCore{
+		LevelEnabler: c.LevelEnabler,
+		conn:         c.conn,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *journaldCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *journaldCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range append(c.fields[:len(c.fields):len(c.fields)], fields...) {
+		field.AddTo(enc)
+	}
+
+	var msg bytes.Buffer
+	writeJournalField(&msg, "MESSAGE", ent.Message)
+	writeJournalField(&msg, "PRIORITY", strconv.Itoa(journalPriorities[ent.Level]))
+	if ent.LoggerName != "" {
+		writeJournalField(&msg, "LOGGER", ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		writeJournalField(&msg, "CODE_FILE", ent.Caller.File)
+		writeJournalField(&msg, "CODE_LINE", strconv.Itoa(ent.Caller.Line))
+		if ent.Caller.Function != "" {
+			writeJournalField(&msg, "CODE_FUNC", ent.Caller.Function)
+		}
+	}
+	if ent.Stack != "" {
+		writeJournalField(&msg, "STACKTRACE", ent.Stack)
+	}
+	keys := make([]string, 0, len(enc.Fields))
+	for key := range enc.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if name := journalFieldName(key); name != "" {
+			writeJournalField(&msg, name, journalFieldValue(enc.Fields[key]))
+		}
+	}
+
+	if _, err := c.conn.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	emittedEntries.Add(1)
+	return nil
+}
+
+// Sync is a no-op, as every entry is sent as soon as it is written.
+func (*journaldCore) Sync() error {
+	return nil
+}
+
+// journalPriorities map zap levels to syslog priorities.
+var journalPriorities = map[zapcore.Level]int{
+	zapcore.DebugLevel:  7, // debug
+	zapcore.InfoLevel:   6, // info
+	zapcore.WarnLevel:   4, // warning
+	zapcore.ErrorLevel:  3, // err
+	zapcore.DPanicLevel: 2, // crit
+	zapcore.PanicLevel:  1, // alert
+	zapcore.FatalLevel:  0, // emerg
+}
+
+// journalFieldName returns the journal field name for a field key. Names consist of upper-case
+// letters, digits and underscores, and may not start with an underscore, which marks trusted fields.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_0123456789")
+	return name[:min(len(name), journalFieldMaxLength)]
+}
+
+// journalFieldValue returns the value of a journal field: strings as they are, and other values as JSON.
+func journalFieldValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// writeJournalField writes a field in the journal native protocol. Values holding a newline are
+// written in the binary form, preceded by their length as a little-endian 64-bit integer.
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
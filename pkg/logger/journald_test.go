@@ -0,0 +1,143 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeJournal listens on a journal socket in a temporary directory.
+type fakeJournal struct {
+	path string
+	conn *net.UnixConn
+}
+
+func newFakeJournal(t *testing.T) *fakeJournal {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return &fakeJournal{path: path, conn: conn}
+}
+
+// receive reads the next entry sent to the journal, and returns its fields.
+func (j *fakeJournal) receive(t *testing.T) map[string]string {
+	t.Helper()
+	buf := make([]byte, 64*1024)
+	n, err := j.conn.Read(buf)
+	require.NoError(t, err)
+	fields, err := parseJournalEntry(buf[:n])
+	require.NoError(t, err)
+	return fields
+}
+
+// parseJournalEntry parses an entry in the journal native protocol.
+func parseJournalEntry(data []byte) (map[string]string, error) {
+	fields := map[string]string{}
+	for len(data) > 0 {
+		line, rest, ok := bytes.Cut(data, []byte("\n"))
+		if !ok {
+			return nil, errors.New("unterminated field")
+		}
+		if name, value, ok := bytes.Cut(line, []byte("=")); ok {
+			fields[string(name)] = string(value)
+			data = rest
+			continue
+		}
+		if len(rest) < 8 {
+			return nil, errors.New("missing field length")
+		}
+		size := binary.LittleEndian.Uint64(rest[:8])
+		if uint64(len(rest)-8) < size+1 {
+			return nil, errors.New("truncated field")
+		}
+		fields[string(line)] = string(rest[8 : 8+size])
+		data = rest[8+size+1:]
+	}
+	return fields, nil
+}
+
+func TestJournaldCore(t *testing.T) {
+	t.Parallel()
+	journal := newFakeJournal(t)
+	core := newJournaldCore(journal.path, zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.DebugLevel)
+	require.IsType(t, &journaldCore{}, core)
+	log := zap.New(core).Named("runner").With(zap.String("server-name", "fetch"))
+
+	tests := []struct {
+		level    zapcore.Level
+		priority string
+	}{
+		{zapcore.DebugLevel, "7"},
+		{zapcore.InfoLevel, "6"},
+		{zapcore.WarnLevel, "4"},
+		{zapcore.ErrorLevel, "3"},
+		{zapcore.DPanicLevel, "2"},
+	}
+	for _, tt := range tests {
+		log.Log(tt.level, "server started", zap.Int("port", 8080))
+
+		fields := journal.receive(t)
+		assert.Equal(t, "server started", fields["MESSAGE"])
+		assert.Equal(t, tt.priority, fields["PRIORITY"], tt.level.String())
+		assert.Equal(t, "runner", fields["LOGGER"])
+		assert.Equal(t, "fetch", fields["SERVER_NAME"])
+		assert.Equal(t, "8080", fields["PORT"])
+	}
+}
+
+func TestJournaldCoreMultilineMessage(t *testing.T) {
+	t.Parallel()
+	journal := newFakeJournal(t)
+	core := newJournaldCore(journal.path, zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.DebugLevel)
+
+	zap.New(core).Error("first line\nsecond line", zap.Strings("_args", []string{"a", "b"}))
+
+	fields := journal.receive(t)
+	assert.Equal(t, "first line\nsecond line", fields["MESSAGE"])
+	assert.Equal(t, "3", fields["PRIORITY"])
+	assert.Equal(t, `["a","b"]`, fields["ARGS"])
+}
+
+func TestJournaldCoreFallback(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "missing")
+
+	core := newJournaldCore(path, zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.InfoLevel)
+	_, isJournal := core.(*journaldCore)
+	assert.False(t, isJournal, "entries are written to stderr when the journal socket is unavailable")
+	assert.True(t, core.Enabled(zapcore.InfoLevel))
+}
+
+func TestJournalFieldName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "SERVER_NAME", journalFieldName("server.name"))
+	assert.Equal(t, "TRUSTED", journalFieldName("_trusted"))
+	assert.Empty(t, journalFieldName("-"))
+	assert.Len(t, journalFieldName(string(bytes.Repeat([]byte("a"), 100))), journalFieldMaxLength)
+}
+
+func TestJournaldFormat(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	t.Setenv(FormatEnvVar, formatJournald)
+	_, err := build()
+	require.NoError(t, err)
+
+	t.Setenv(FormatEnvVar, "xml")
+	_, err = build()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported LOG_FORMAT "xml"`)
+}
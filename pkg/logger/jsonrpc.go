@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+// StartJSONRPCCall begins timing the JSON-RPC call req, and returns a function which logs the call
+// once called with its response. The entry carries the method, the request id, the round-trip
+// latency and the params, with the default sensitive fields and the given redactFields redacted,
+// matched case-insensitively at any depth. It is logged at debug level, or at warn level with the
+// code and message of the error if the response carries one.
+//
+//	done := l.StartJSONRPCCall(req, "arguments")
+//	resp, err := send(req)
+//	done(resp)
+func (l *Logger) StartJSONRPCCall(req *jsonrpc2.Request, redactFields ...string) func(resp *jsonrpc2.Response) {
+	start := now()
+	return func(resp *jsonrpc2.Response) {
+		latency := since(start)
+		// Skip the returned function, so that entries report the caller which ended the call.
+		logger := l.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+		fields := []any{zap.String("method", req.Method), zap.Duration("latency", latency)}
+		if req.ID.IsValid() {
+			fields = append(fields, zap.Any("id", req.ID.Raw()))
+		}
+		if len(req.Params) > 0 {
+			fields = append(fields, zap.String("params", jsonRPCRedaction(redactFields).redact(req.Params, false)))
+		}
+
+		if resp != nil && resp.Error != nil {
+			if code, ok := jsonRPCErrorCode(resp.Error); ok {
+				fields = append(fields, zap.Int64("error_code", code))
+			}
+			logger.Warnw("jsonrpc call failed", append(fields, zap.Error(resp.Error))...)
+			return
+		}
+		logger.Debugw("jsonrpc call", fields...)
+	}
+}
+
+// jsonRPCRedaction returns the options redacting the default sensitive fields and the given fields.
+func jsonRPCRedaction(redactFields []string) httpBodyOptions {
+	opts := httpBodyOptions{fields: map[string]bool{}}
+	for _, field := range defaultSensitiveBodyFields {
+		opts.fields[field] = true
+	}
+	for _, field := range redactFields {
+		opts.fields[strings.ToLower(field)] = true
+	}
+	return opts
+}
+
+// jsonRPCErrorCode returns the code of a JSON-RPC error object. Errors built by jsonrpc2.NewError,
+// or decoded from a response, encode to the error object along with their code.
+func jsonRPCErrorCode(err error) (int64, bool) {
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		return 0, false
+	}
+	var object struct {
+		Code *int64 `json:"code"`
+	}
+	if json.Unmarshal(data, &object) != nil || object.Code == nil {
+		return 0, false
+	}
+	return *object.Code, true
+}
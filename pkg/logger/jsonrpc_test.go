@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/exp/jsonrpc2"
+)
+
+func TestStartJSONRPCCall(t *testing.T) { //nolint:paralleltest // Replaces the global clock
+	clock := newFakeClock()
+	defer SetClock(clock)()
+
+	decode := func(t *testing.T, data string) jsonrpc2.Message {
+		t.Helper()
+		msg, err := jsonrpc2.DecodeMessage([]byte(data))
+		require.NoError(t, err)
+		return msg
+	}
+
+	tests := []struct {
+		name      string
+		response  string
+		level     zapcore.Level
+		message   string
+		errorCode any
+	}{
+		{
+			name:     "Result",
+			response: `{"jsonrpc":"2.0","id":7,"result":{"content":[]}}`,
+			level:    zapcore.DebugLevel,
+			message:  "jsonrpc call",
+		},
+		{
+			name:      "Error",
+			response:  `{"jsonrpc":"2.0","id":7,"error":{"code":-32602,"message":"invalid params"}}`,
+			level:     zapcore.WarnLevel,
+			message:   "jsonrpc call failed",
+			errorCode: int64(-32602),
+		},
+	}
+	for _, tt := range tests { //nolint:paralleltest // Replaces the global clock
+		t.Run(tt.name, func(t *testing.T) {
+			l, logs := newObservedLogger(zapcore.DebugLevel)
+
+			req := decode(t, `{"jsonrpc":"2.0","id":7,"method":"tools/call",`+
+				`"params":{"name":"fetch","arguments":{"url":"https://example.com","token":"s3cret"}}}`)
+			done := l.StartJSONRPCCall(req.(*jsonrpc2.Request), "url")
+			clock.Advance(250 * time.Millisecond)
+			done(decode(t, tt.response).(*jsonrpc2.Response))
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			entry := entries[0]
+			assert.Equal(t, tt.level, entry.Level)
+			assert.Equal(t, tt.message, entry.Message)
+			fields := entry.ContextMap()
+			assert.Equal(t, "tools/call", fields["method"])
+			assert.Equal(t, int64(7), fields["id"])
+			assert.Equal(t, 250*time.Millisecond, fields["latency"])
+			assert.JSONEq(t, `{"name":"fetch","arguments":{"url":"[REDACTED]","token":"[REDACTED]"}}`, fields["params"].(string))
+			assert.Equal(t, tt.errorCode, fields["error_code"])
+			if tt.errorCode != nil {
+				assert.Equal(t, "invalid params", fields["error"])
+			}
+		})
+	}
+}
+
+func TestStartJSONRPCCallNotification(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	req, err := jsonrpc2.NewNotification("notifications/initialized", nil)
+	require.NoError(t, err)
+	l.StartJSONRPCCall(req)(nil)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "notifications/initialized", entries[0].ContextMap()["method"])
+	assert.NotContains(t, entries[0].ContextMap(), "id")
+	assert.NotContains(t, entries[0].ContextMap(), "params")
+}
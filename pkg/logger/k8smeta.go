@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+)
+
+// K8sMetadataEnvVar is the environment variable which, when set to true, adds the pod metadata
+// exposed through the Kubernetes downward API to every entry.
+const K8sMetadataEnvVar = "LOG_K8S_METADATA"
+
+// k8sMetadataFields map the downward API environment variables to the fields they are logged as.
+var k8sMetadataFields = []struct {
+	envVar string
+	key    string
+}{
+	{"POD_NAME", "pod_name"},
+	{"POD_NAMESPACE", "pod_namespace"},
+	{"NODE_NAME", "node_name"},
+}
+
+// k8sMetadata returns the pod metadata fields to add to every entry, omitting those whose
+// environment variable is unset or empty. It returns nil unless enabled through K8sMetadataEnvVar.
+func k8sMetadata() map[string]any {
+	if enabled, _ := strconv.ParseBool(os.Getenv(K8sMetadataEnvVar)); !enabled {
+		return nil
+	}
+	fields := map[string]any{}
+	for _, field := range k8sMetadataFields {
+		if value := os.Getenv(field.envVar); value != "" {
+			fields[field.key] = value
+		}
+	}
+	return fields
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logStructuredEntry builds a structured logger from the environment, logs one entry with it
+// and returns the decoded entry. It redirects stdout, so callers must not run in parallel.
+func logStructuredEntry(t *testing.T) map[string]any {
+	t.Helper()
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	out, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	require.NoError(t, err)
+	defer out.Close()
+	oldStdout := os.Stdout
+	os.Stdout = out
+	defer func() { os.Stdout = oldStdout }()
+
+	l, err := build()
+	require.NoError(t, err)
+	l.Info("test message")
+	_ = l.Sync()
+
+	data, err := os.ReadFile(out.Name())
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(data, &entry), "entry must be valid JSON: %s", data)
+	return entry
+}
+
+func TestK8sMetadata(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Run("Present", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(K8sMetadataEnvVar, "true")
+		t.Setenv("POD_NAME", "toolhive-operator-7d9f8")
+		t.Setenv("POD_NAMESPACE", "toolhive-system")
+		t.Setenv("NODE_NAME", "worker-1")
+
+		entry := logStructuredEntry(t)
+		assert.Equal(t, "toolhive-operator-7d9f8", entry["pod_name"])
+		assert.Equal(t, "toolhive-system", entry["pod_namespace"])
+		assert.Equal(t, "worker-1", entry["node_name"])
+	})
+
+	t.Run("PartiallyPresent", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(K8sMetadataEnvVar, "true")
+		t.Setenv("POD_NAME", "toolhive-operator-7d9f8")
+		t.Setenv("POD_NAMESPACE", "")
+		t.Setenv("NODE_NAME", "")
+
+		entry := logStructuredEntry(t)
+		assert.Equal(t, "toolhive-operator-7d9f8", entry["pod_name"])
+		assert.NotContains(t, entry, "pod_namespace")
+		assert.NotContains(t, entry, "node_name")
+	})
+
+	t.Run("Unset", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(K8sMetadataEnvVar, "true")
+		t.Setenv("POD_NAME", "")
+		t.Setenv("POD_NAMESPACE", "")
+		t.Setenv("NODE_NAME", "")
+
+		entry := logStructuredEntry(t)
+		assert.NotContains(t, entry, "pod_name")
+		assert.NotContains(t, entry, "pod_namespace")
+		assert.NotContains(t, entry, "node_name")
+	})
+
+	t.Run("Disabled", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(K8sMetadataEnvVar, "")
+		t.Setenv("POD_NAME", "toolhive-operator-7d9f8")
+
+		entry := logStructuredEntry(t)
+		assert.NotContains(t, entry, "pod_name")
+	})
+}
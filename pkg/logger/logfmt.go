@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// logfmtBufferPool provides the buffers logfmt entries are encoded into.
+var logfmtBufferPool = buffer.NewPool()
+
+// logfmtEncoder encodes entries as logfmt lines of key=value pairs, e.g.
+// level=info ts=1735732800 msg="server started" server=fetch. Entries are encoded as JSON first
+// and then rewritten, so that fields are encoded exactly as in JSON output; nested objects are
+// flattened into dotted keys and arrays are kept as quoted JSON.
+type logfmtEncoder struct {
+	zapcore.Encoder
+}
+
+func newLogfmtEncoder(config zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{Encoder: zapcore.NewJSONEncoder(config)}
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	return &logfmtEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	encoded, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer encoded.Free()
+
+	line := logfmtBufferPool.Get()
+	if err := appendLogfmt(line, "", encoded.Bytes()); err != nil {
+		line.Free()
+		return nil, err
+	}
+	line.AppendByte('\n')
+	return line, nil
+}
+
+// appendLogfmt appends the members of the JSON object in data to line as logfmt pairs, in order,
+// prefixing their keys with prefix.
+func appendLogfmt(line *buffer.Buffer, prefix string, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// The opening brace of the object.
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := prefix + token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		switch value[0] {
+		case '{':
+			if err := appendLogfmt(line, key+".", value); err != nil {
+				return err
+			}
+			continue
+		case '"':
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return err
+			}
+			appendLogfmtPair(line, key, s)
+		default:
+			appendLogfmtPair(line, key, string(value))
+		}
+	}
+	return nil
+}
+
+// appendLogfmtPair appends key=value to line, quoting the value if needed.
+func appendLogfmtPair(line *buffer.Buffer, key, value string) {
+	if line.Len() > 0 {
+		line.AppendByte(' ')
+	}
+	line.AppendString(key)
+	line.AppendByte('=')
+	if value == "" || strings.ContainsAny(value, " =\"\\\t\r\n") || !strconv.CanBackquote(value) {
+		line.AppendString(strconv.Quote(value))
+		return
+	}
+	line.AppendString(value)
+}
@@ -2,12 +2,12 @@
 package logger
 
 import (
+	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
-	"github.com/go-logr/logr"
-	"github.com/go-logr/zapr"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -118,21 +118,29 @@ func Fatalw(msg string, keysAndValues ...any) {
 	zap.S().Fatalw(msg, keysAndValues...)
 }
 
-// NewLogr returns a logr.Logger which uses zap logger
-func NewLogr() logr.Logger {
-	return zapr.NewLogger(zap.L())
-}
-
 // Initialize creates and configures the appropriate logger.
 // If the UNSTRUCTURED_LOGS is set to true, it will output plain log message
 // with only time and LogLevelType (INFO, DEBUG, ERROR, WARN)).
 // Otherwise it will create a standard structured slog logger
 func Initialize() {
+	if size := ringBufferSize(); size > 0 {
+		recentLogs.Store(newRingBuffer(size))
+	} else {
+		recentLogs.Store(nil)
+	}
+	zap.ReplaceGlobals(zap.Must(build()))
+}
+
+// build creates a zap logger configured from the environment.
+func build() (*zap.Logger, error) {
 	var config zap.Config
 	if unstructuredLogs() {
 		config = zap.NewDevelopmentConfig()
 		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 		config.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout(time.Kitchen)
+		// zap.Open opens the output paths as a single zapcore.Lock'ed write syncer, and each
+		// entry is encoded into one buffer before it is written, so concurrent entries
+		// never interleave on stderr.
 		config.OutputPaths = []string{"stderr"}
 		config.DisableStacktrace = true
 		config.DisableCaller = true
@@ -141,6 +149,8 @@ func Initialize() {
 		config.OutputPaths = []string{"stdout"}
 	}
 
+	config.InitialFields = initialFields()
+
 	// Set log level based on current debug flag
 	if viper.GetBool("debug") {
 		config.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
@@ -148,7 +158,180 @@ func Initialize() {
 		config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
 	}
 
-	zap.ReplaceGlobals(zap.Must(config.Build()))
+	return newFromConfig(config)
+}
+
+// newFromConfig builds a logger from config in the same way as zap.Config.Build, but with
+// the encoder and output instrumented for Stats and wrapped with the package's own cores.
+func newFromConfig(config zap.Config) (*zap.Logger, error) {
+	enc, err := newEncoder(config.Encoding, config.EncoderConfig)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := logOutputsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	journald, err := journaldFormat()
+	if err != nil {
+		return nil, err
+	}
+	errSink, _, err := zap.Open(config.ErrorOutputPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	var core zapcore.Core
+	var files []*reopenableFile
+	switch {
+	case windowsEventLog():
+		writer, err := openEventLog()
+		if err != nil {
+			return nil, err
+		}
+		core = newEventLogCore(&countingEncoder{Encoder: enc}, config.Level, writer)
+	case journald:
+		core = newJournaldCore(journalSocketPath, enc, config.Level)
+	case len(outputs) > 0:
+		core, files, err = newOutputsCore(outputs, config)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		sink, _, err := zap.Open(config.OutputPaths...)
+		if err != nil {
+			return nil, err
+		}
+		core = newOutputCore(enc, sink, config.Level)
+	}
+
+	core, err = wrapCore(core, config.Level, config.Sampling)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		core = &fileOutputsCore{Core: core, files: files}
+	}
+	return zap.New(core, buildOptions(config, errSink)...), nil
+}
+
+// newEncoder returns the encoder for the given format: "json", "console" or "logfmt".
+func newEncoder(format string, config zapcore.EncoderConfig) (zapcore.Encoder, error) {
+	switch format {
+	case formatConsole:
+		return zapcore.NewConsoleEncoder(config), nil
+	case formatJSON:
+		return zapcore.NewJSONEncoder(config), nil
+	case formatLogfmt:
+		return newLogfmtEncoder(config), nil
+	default:
+		return nil, fmt.Errorf("unsupported log encoding: %s", format)
+	}
+}
+
+// newOutputCore returns the core writing entries encoded by enc to sink, instrumented for Stats
+// and buffered if an async buffer is configured.
+func newOutputCore(enc zapcore.Encoder, sink zapcore.WriteSyncer, level zapcore.LevelEnabler) zapcore.Core {
+	var out zapcore.WriteSyncer = &countingWriteSyncer{WriteSyncer: sink}
+	if size := asyncBufferSize(); size > 0 {
+		out = newAsyncWriteSyncer(out, size)
+	}
+	return zapcore.NewCore(&countingEncoder{Encoder: enc}, out, level)
+}
+
+// buildOptions returns the options zap.Config.Build would derive from config.
+func buildOptions(config zap.Config, errSink zapcore.WriteSyncer) []zap.Option {
+	opts := []zap.Option{zap.ErrorOutput(errSink), zap.WithClock(getClock())}
+	if config.Development {
+		opts = append(opts, zap.Development())
+	}
+	if !config.DisableCaller {
+		opts = append(opts, zap.AddCaller())
+	}
+	if !config.DisableStacktrace {
+		stackLevel := zap.ErrorLevel
+		if config.Development {
+			stackLevel = zap.WarnLevel
+		}
+		opts = append(opts, zap.AddStacktrace(stackLevel))
+	}
+	if len(config.InitialFields) > 0 {
+		keys := make([]string, 0, len(config.InitialFields))
+		for key := range config.InitialFields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]zap.Field, 0, len(keys))
+		for _, key := range keys {
+			fields = append(fields, zap.Any(key, config.InitialFields[key]))
+		}
+		opts = append(opts, zap.Fields(fields...))
+	}
+	return opts
+}
+
+// wrapCore decorates the core built from the zap config with the cores provided by this package.
+// Sampling, when configured, is applied last so that it decides on fully decorated entries,
+// except for entries which are only enabled because their component was elevated by ElevateFor,
+// and for entries logged while an error spike has elevated logging.
+func wrapCore(core zapcore.Core, level zapcore.LevelEnabler, sampling *zap.SamplingConfig) (zapcore.Core, error) {
+	if buffer := recentLogs.Load(); buffer != nil {
+		core = zapcore.NewTee(core, newRingBufferCore(level, buffer))
+	}
+	provider, err := otlpProviderFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if provider != nil {
+		core = zapcore.NewTee(core, newOTLPCore(level, provider))
+	}
+	if notifier := newWebhookNotifierFromEnv(); notifier != nil {
+		core = zapcore.NewTee(core, newWebhookCore(level, notifier))
+	}
+
+	core = newClassifyingCore(core)
+	if limit := fieldKeyLimit(); limit > 0 {
+		core = newCardinalityCore(core, limit)
+	}
+	if window := stacktraceDedupWindow(); window > 0 {
+		core = newStackDedupCore(core, window)
+	}
+	if callerPackageEnabled() {
+		core = newCallerPackageCore(core)
+	}
+	if severityNumberEnabled() {
+		core = newSeverityCore(core)
+	}
+	if budget := logBudget(); budget > 0 {
+		core = newBudgetCore(core, budget)
+	}
+	if sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter,
+			zapcore.SamplerHook(countSamplingDecision))
+	}
+	if threshold, window, cooldown := errorSpikeSettings(); threshold > 0 {
+		core = newErrorSpikeCore(core, threshold, window, cooldown)
+	}
+	return newComponentLevelCore(core, elevatedLevels), nil
+}
+
+// initialFields returns the fields added to every entry, sourced from the environment.
+func initialFields() map[string]any {
+	fields := k8sMetadata()
+	for key, value := range map[string]string{
+		RunIDKey:         runID(),
+		SchemaVersionKey: schemaVersion(),
+		DeploymentKey:    deployment(),
+	} {
+		if value == "" {
+			continue
+		}
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		fields[key] = value
+	}
+	return fields
 }
 
 func unstructuredLogs() bool {
@@ -6,10 +6,12 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestUnstructuredLogsCheck tests the unstructuredLogs function
@@ -454,3 +456,53 @@ func TestInitialize(t *testing.T) { //nolint:paralleltest // Uses environment va
 		}
 	})
 }
+
+// TestUnstructuredLoggerConcurrentWrites ensures concurrent console entries never interleave.
+// Entries are larger than PIPE_BUF, so the kernel does not guarantee that unlocked writes are atomic.
+func TestUnstructuredLoggerConcurrentWrites(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	const (
+		goroutines = 32
+		iterations = 20
+	)
+	payload := strings.Repeat("x", 8192)
+
+	os.Setenv("UNSTRUCTURED_LOGS", "true")
+	defer os.Unsetenv("UNSTRUCTURED_LOGS")
+
+	originalStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	// Drain the pipe while logging so that writers never block on a full pipe
+	output := make(chan string)
+	go func() {
+		var capturedOutput bytes.Buffer
+		io.Copy(&capturedOutput, r)
+		output <- capturedOutput.String()
+	}()
+
+	Initialize()
+
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				Infof("goroutine=%03d %s", i, payload)
+			}
+		}()
+	}
+	wg.Wait()
+
+	w.Close()
+	os.Stderr = originalStderr
+
+	lines := strings.Split(strings.TrimSuffix(<-output, "\n"), "\n")
+	require.Len(t, lines, goroutines*iterations)
+	for _, line := range lines {
+		assert.Equal(t, 1, strings.Count(line, "goroutine="), "entry interleaved with another entry")
+		assert.True(t, strings.HasSuffix(line, payload), "entry was truncated or interleaved")
+		assert.Contains(t, line, "INFO")
+	}
+}
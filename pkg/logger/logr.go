@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logrSink implements logr.LogSink on top of a zap logger, so that libraries which accept a
+// logr.Logger, such as controller-runtime, log through ToolHive's logger.
+type logrSink struct {
+	logger *zap.Logger
+	// callerSkip is the number of frames between the caller and the zap logger.
+	callerSkip int
+}
+
+// NewLogrSink returns a logr.LogSink which writes to l. Verbosity level 0 maps to zap's info
+// level and every higher verbosity level maps to debug, so that V(1) and above are only logged
+// when debug logging is enabled. Key-value pairs are converted to fields as by l.Infow.
+func NewLogrSink(l *Logger) logr.LogSink {
+	// One frame for the sink itself, in addition to the logr frames given to Init.
+	return &logrSink{logger: l.Desugar(), callerSkip: 1}
+}
+
+// NewLogr returns a logr.Logger which writes to the global logger set up by Initialize.
+func NewLogr() logr.Logger {
+	return logr.New(NewLogrSink(&Logger{SugaredLogger: zap.S()}))
+}
+
+// Init records the number of logr frames above the sink, so that callers are reported correctly.
+func (s *logrSink) Init(info logr.RuntimeInfo) {
+	s.callerSkip += info.CallDepth
+}
+
+// Enabled reports whether entries at the given verbosity level are logged.
+func (s *logrSink) Enabled(level int) bool {
+	return s.logger.Core().Enabled(logrLevel(level))
+}
+
+// Info logs a message at the zap level mapped from the given verbosity level.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...any) {
+	s.sugar().Logw(logrLevel(level), msg, keysAndValues...)
+}
+
+// Error logs a message at error level, with err as the "error" field.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...any) {
+	s.sugar().With(zap.Error(err)).Errorw(msg, keysAndValues...)
+}
+
+// WithValues returns a sink which adds the given key-value pairs to every entry.
+func (s *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &logrSink{logger: s.logger.Sugar().With(keysAndValues...).Desugar(), callerSkip: s.callerSkip}
+}
+
+// WithName returns a sink whose logger name is extended with name.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	return &logrSink{logger: s.logger.Named(name), callerSkip: s.callerSkip}
+}
+
+// WithCallDepth returns a sink which skips depth additional frames when reporting callers.
+func (s *logrSink) WithCallDepth(depth int) logr.LogSink {
+	return &logrSink{logger: s.logger, callerSkip: s.callerSkip + depth}
+}
+
+func (s *logrSink) sugar() *zap.SugaredLogger {
+	return s.logger.WithOptions(zap.AddCallerSkip(s.callerSkip)).Sugar()
+}
+
+// logrLevel maps a logr verbosity level to a zap level.
+func logrLevel(level int) zapcore.Level {
+	if level <= 0 {
+		return zapcore.InfoLevel
+	}
+	return zapcore.DebugLevel
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogrSink(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := &Logger{SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()}
+	log := logr.New(NewLogrSink(l)).WithName("controller").WithValues("namespace", "default")
+
+	log.Info("reconciling", "name", "fetch", "attempt", 2)
+	log.Error(errors.New("not found"), "reconcile failed", "name", "fetch")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "reconciling", entries[0].Message)
+	assert.Equal(t, "controller", entries[0].LoggerName)
+	assert.Equal(t, map[string]any{"namespace": "default", "name": "fetch", "attempt": int64(2)}, entries[0].ContextMap())
+	assert.Contains(t, entries[0].Caller.File, "logr_test.go")
+
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	assert.Equal(t, "reconcile failed", entries[1].Message)
+	assert.Equal(t, map[string]any{"namespace": "default", "name": "fetch", "error": "not found"}, entries[1].ContextMap())
+}
+
+func TestLogrSinkVerbosity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		level     zapcore.Level
+		verbosity int
+		expected  zapcore.Level
+		enabled   bool
+	}{
+		{name: "V0AtInfo", level: zapcore.InfoLevel, verbosity: 0, expected: zapcore.InfoLevel, enabled: true},
+		{name: "V1AtInfo", level: zapcore.InfoLevel, verbosity: 1, enabled: false},
+		{name: "V1AtDebug", level: zapcore.DebugLevel, verbosity: 1, expected: zapcore.DebugLevel, enabled: true},
+		{name: "V4AtDebug", level: zapcore.DebugLevel, verbosity: 4, expected: zapcore.DebugLevel, enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l, logs := newObservedLogger(tt.level)
+			log := logr.New(NewLogrSink(l)).V(tt.verbosity)
+
+			assert.Equal(t, tt.enabled, log.Enabled())
+			log.Info("message")
+
+			if !tt.enabled {
+				assert.Zero(t, logs.Len())
+				return
+			}
+			require.Equal(t, 1, logs.Len())
+			assert.Equal(t, tt.expected, logs.All()[0].Level)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package logtest provides helpers for tests which exercise code logging through the logger package.
+package logtest
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// TestKey is the key of the field holding the name of the test an entry was logged by.
+const TestKey = "test"
+
+// LoggerForTest returns a Logger configured from the environment as by logger.NewLogger, which
+// adds the name of t, including the names of its parent tests, to every entry as TestKey, so that
+// the interleaved output of parallel tests can be told apart. The logger is synced once t and its
+// subtests have completed.
+func LoggerForTest(t *testing.T) *logger.Logger {
+	t.Helper()
+	l := logger.NewLogger(zap.Fields(zap.String(TestKey, t.Name())))
+	// Syncing stdout may fail depending on what it is attached to, which does not affect the test.
+	t.Cleanup(func() { _ = l.Sync() })
+	return l
+}
@@ -0,0 +1,46 @@
+package logtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerForTest(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	// The logger writes to stdout, which is replaced to capture its output.
+	originalStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = originalStdout })
+
+	parent := LoggerForTest(t)
+	parent.Infow("starting", "step", 1)
+	t.Run("Subtest", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		LoggerForTest(t).Info("in subtest")
+	})
+	require.NoError(t, w.Close())
+	os.Stdout = originalStdout
+
+	var entries []map[string]any
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "starting", entries[0]["msg"])
+	assert.Equal(t, "TestLoggerForTest", entries[0][TestKey])
+	assert.Equal(t, float64(1), entries[0]["step"])
+	assert.Equal(t, "in subtest", entries[1]["msg"])
+	assert.Equal(t, "TestLoggerForTest/Subtest", entries[1][TestKey])
+}
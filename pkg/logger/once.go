@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// onceKeys holds the keys WarnOnce has emitted entries for.
+var onceKeys sync.Map
+
+// WarnOnce emits a warn entry the first time it is called with the given key, and does nothing on
+// later calls, so that notices such as deprecation warnings appear once per process however often
+// their code path runs. Keys are shared by all loggers in the process.
+func (l *Logger) WarnOnce(key, msg string, keysAndValues ...any) {
+	if _, seen := onceKeys.LoadOrStore(key, struct{}{}); seen {
+		return
+	}
+	l.WithOptions(zap.AddCallerSkip(1)).Warnw(msg, keysAndValues...)
+}
+
+// ResetOnceKeys forgets the keys WarnOnce has emitted entries for, so that each emits again.
+// It is intended for tests.
+func ResetOnceKeys() {
+	onceKeys.Clear()
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWarnOnce(t *testing.T) { //nolint:paralleltest // Resets the once keys
+	ResetOnceKeys()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.WarnOnce("deprecated-flag", "--foo is deprecated", "replacement", "--bar")
+		}()
+	}
+	wg.Wait()
+	l.WarnOnce("deprecated-config", "auto_discovery is deprecated")
+	l.WarnOnce("deprecated-config", "auto_discovery is deprecated")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "--foo is deprecated", entries[0].Message)
+	assert.Equal(t, "--bar", entries[0].ContextMap()["replacement"])
+	assert.Equal(t, "auto_discovery is deprecated", entries[1].Message)
+}
+
+func TestResetOnceKeys(t *testing.T) { //nolint:paralleltest // Resets the once keys
+	ResetOnceKeys()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	l.WarnOnce("notice", "shown once")
+	l.WarnOnce("notice", "shown once")
+	ResetOnceKeys()
+	l.WarnOnce("notice", "shown once")
+
+	assert.Equal(t, 2, logs.Len())
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"reflect"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// changeValues holds the last value LogOnChange was called with for each key.
+	changeValues     = map[string]any{}
+	changeValuesLock sync.Mutex
+)
+
+// LogOnChange emits an info entry with msg when value differs from the one it was last called with
+// for key, with the previous value as old and the current one as new, so that state polled in a
+// loop, such as a server's health, is only logged when it changes. The first call for a key emits
+// an entry with new only. Values are compared with reflect.DeepEqual, and keys are shared by all
+// loggers in the process.
+func (l *Logger) LogOnChange(key string, value any, msg string) {
+	changeValuesLock.Lock()
+	old, seen := changeValues[key]
+	changed := !seen || !reflect.DeepEqual(old, value)
+	changeValues[key] = value
+	changeValuesLock.Unlock()
+	if !changed {
+		return
+	}
+
+	logger := l.Desugar().WithOptions(zap.AddCallerSkip(1))
+	if !seen {
+		logger.Info(msg, zap.Any("new", value))
+		return
+	}
+	logger.Info(msg, zap.Any("old", old), zap.Any("new", value))
+}
+
+// ResetChangeValues forgets the values LogOnChange has been called with, so that each emits again.
+// It is intended for tests.
+func ResetChangeValues() {
+	changeValuesLock.Lock()
+	defer changeValuesLock.Unlock()
+	clear(changeValues)
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogOnChange(t *testing.T) { //nolint:paralleltest // Resets the change values
+	ResetChangeValues()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	for range 3 {
+		l.LogOnChange("fetch-health", "healthy", "server health changed")
+	}
+	l.LogOnChange("fetch-health", "unhealthy", "server health changed")
+	l.LogOnChange("fetch-health", "unhealthy", "server health changed")
+	l.LogOnChange("github-health", "healthy", "server health changed")
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "server health changed", entries[0].Message)
+	assert.Equal(t, map[string]any{"new": "healthy"}, entries[0].ContextMap())
+	assert.Equal(t, map[string]any{"old": "healthy", "new": "unhealthy"}, entries[1].ContextMap())
+	assert.Equal(t, map[string]any{"new": "healthy"}, entries[2].ContextMap(), "keys are tracked separately")
+}
+
+func TestResetChangeValues(t *testing.T) { //nolint:paralleltest // Resets the change values
+	ResetChangeValues()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	l.LogOnChange("replicas", 3, "replicas changed")
+	ResetChangeValues()
+	l.LogOnChange("replicas", 3, "replicas changed")
+
+	assert.Equal(t, 2, logs.Len())
+}
@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"github.com/google/uuid"
+)
+
+// OperationIDKey is the key of the field holding the ID of the operation an entry was logged for.
+const OperationIDKey = "operation_id"
+
+// NewOperation generates an ID for an operation which may span several goroutines, and returns a
+// child of l adding it to every entry as OperationIDKey, along with the ID so that it can be
+// propagated to work done outside of the child logger, e.g. passed to another process. Goroutines
+// spawned for the operation through SafeGo with the child logger share the ID:
+//
+//	opLogger, opID := logger.NewOperation(l)
+//	logger.SafeGo(opLogger, func() { opLogger.Info("pulling image") })
+func NewOperation(l *Logger) (*Logger, string) {
+	id := uuid.NewString()
+	return l.With(OperationIDKey, id), id
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewOperation(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	opLogger, opID := NewOperation(l)
+	require.NotEmpty(t, opID)
+	opLogger.Info("starting workload")
+	done := make(chan struct{})
+	SafeGo(opLogger, func() {
+		defer close(done)
+		opLogger.With("step", "pull").Info("pulling image")
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("spawned goroutine did not finish")
+	}
+	l.Info("unrelated")
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, opID, entries[0].ContextMap()[OperationIDKey])
+	assert.Equal(t, opID, entries[1].ContextMap()[OperationIDKey], "spawned goroutines share the operation ID")
+	assert.NotContains(t, entries[2].ContextMap(), OperationIDKey)
+
+	_, otherID := NewOperation(l)
+	assert.NotEqual(t, opID, otherID)
+}
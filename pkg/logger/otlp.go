@@ -0,0 +1,205 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// OTLPEndpointEnvVar is the standard OpenTelemetry environment variable holding the base URL
+	// of the OTLP/HTTP endpoint, e.g. http://localhost:4318. Log records are exported to its
+	// /v1/logs path. When neither it nor OTLPLogsEndpointEnvVar is set, logs are not exported.
+	OTLPEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// OTLPLogsEndpointEnvVar is the standard OpenTelemetry environment variable holding the full
+	// URL log records are exported to. It takes precedence over OTLPEndpointEnvVar.
+	OTLPLogsEndpointEnvVar = "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"
+
+	otlpServiceNameEnvVar = "OTEL_SERVICE_NAME"
+	otlpDefaultService    = "toolhive"
+	otlpScopeName         = "github.com/stacklok/toolhive/pkg/logger"
+	otlpFlushInterval     = time.Second
+	otlpMaxPending        = 2048
+	otlpExportTimeout     = 10 * time.Second
+)
+
+// otlpSeverities maps zap levels to OpenTelemetry severity numbers.
+var otlpSeverities = map[zapcore.Level]otellog.Severity{
+	zapcore.DebugLevel:  otellog.SeverityDebug,
+	zapcore.InfoLevel:   otellog.SeverityInfo,
+	zapcore.WarnLevel:   otellog.SeverityWarn,
+	zapcore.ErrorLevel:  otellog.SeverityError,
+	zapcore.DPanicLevel: otellog.SeverityFatal,
+	zapcore.PanicLevel:  otellog.SeverityFatal2,
+	zapcore.FatalLevel:  otellog.SeverityFatal3,
+}
+
+var (
+	otlpProvidersMu sync.Mutex
+	// otlpProviders holds the logger provider of every endpoint logs have been exported to,
+	// so that loggers built for the same endpoint share one exporter for the lifetime of the process.
+	otlpProviders = map[string]*sdklog.LoggerProvider{}
+)
+
+// otlpProviderFromEnv returns the logger provider exporting to the endpoint configured in the
+// environment, or nil if none is configured.
+func otlpProviderFromEnv() (*sdklog.LoggerProvider, error) {
+	url := otlpLogsURL()
+	if url == "" {
+		return nil, nil
+	}
+
+	otlpProvidersMu.Lock()
+	defer otlpProvidersMu.Unlock()
+	if provider, ok := otlpProviders[url]; ok {
+		return provider, nil
+	}
+	exporter, err := otlploghttp.New(context.Background(),
+		otlploghttp.WithEndpointURL(url), otlploghttp.WithTimeout(otlpExportTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
+	}
+	provider := newOTLPProvider(exporter)
+	otlpProviders[url] = provider
+	return provider, nil
+}
+
+// newOTLPProvider returns a logger provider which batches log records and hands them to exporter.
+// Records are exported periodically in the background, and whenever a core using the provider
+// is synced. Records logged while too many are pending are dropped.
+func newOTLPProvider(exporter sdklog.Exporter) *sdklog.LoggerProvider {
+	serviceName := os.Getenv(otlpServiceNameEnvVar)
+	if serviceName == "" {
+		serviceName = otlpDefaultService
+	}
+	processor := sdklog.NewBatchProcessor(exporter,
+		sdklog.WithExportInterval(otlpFlushInterval),
+		sdklog.WithMaxQueueSize(otlpMaxPending),
+		sdklog.WithExportTimeout(otlpExportTimeout))
+	return sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(processor),
+		sdklog.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))))
+}
+
+// otlpCore converts entries into OpenTelemetry log records and emits them through a logger provider.
+type otlpCore struct {
+	zapcore.LevelEnabler
+	fields   []zapcore.Field
+	provider *sdklog.LoggerProvider
+	logger   otellog.Logger
+}
+
+func newOTLPCore(enabler zapcore.LevelEnabler, provider *sdklog.LoggerProvider) zapcore.Core {
+	return &otlpCore{LevelEnabler: enabler, provider: provider, logger: provider.Logger(otlpScopeName)}
+}
+
+func (c *otlpCore) With(fields []zapcore.Field) zapcore.Core {
+	return &otlpCore{
+		LevelEnabler: c.LevelEnabler,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+		provider:     c.provider,
+		logger:       c.logger,
+	}
+}
+
+func (c *otlpCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *otlpCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(ent.Time)
+	record.SetObservedTimestamp(now())
+	record.SetSeverity(otlpSeverities[ent.Level])
+	record.SetSeverityText(ent.Level.CapitalString())
+	record.SetBody(otellog.StringValue(ent.Message))
+	if ent.LoggerName != "" {
+		record.AddAttributes(otellog.String("logger", ent.LoggerName))
+	}
+	for key, value := range enc.Fields {
+		record.AddAttributes(otellog.KeyValue{Key: key, Value: otlpValue(value)})
+	}
+	c.logger.Emit(context.Background(), record)
+
+	// Entries above error level may be followed by the process exiting, so export them right away.
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+// Sync exports every pending record of the provider, including those emitted by other loggers sharing it.
+func (c *otlpCore) Sync() error {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	return c.provider.ForceFlush(ctx)
+}
+
+// otlpValue converts a value produced by zapcore.MapObjectEncoder into an OpenTelemetry log value.
+func otlpValue(value any) otellog.Value {
+	switch v := value.(type) {
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int, int8, int16, int32, int64:
+		return otellog.Int64Value(reflect.ValueOf(v).Int())
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return otellog.Int64Value(int64(reflect.ValueOf(v).Uint())) //nolint:gosec // OTLP integers are signed
+	case float32:
+		return otellog.Float64Value(float64(v))
+	case float64:
+		return otellog.Float64Value(v)
+	case []byte:
+		return otellog.BytesValue(v)
+	case time.Time:
+		return otellog.StringValue(v.Format(time.RFC3339Nano))
+	case []any:
+		values := make([]otellog.Value, 0, len(v))
+		for _, item := range v {
+			values = append(values, otlpValue(item))
+		}
+		return otellog.SliceValue(values...)
+	case map[string]any:
+		values := make([]otellog.KeyValue, 0, len(v))
+		for key, item := range v {
+			values = append(values, otellog.KeyValue{Key: key, Value: otlpValue(item)})
+		}
+		return otellog.MapValue(values...)
+	default:
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+}
+
+// otlpLogsURL returns the URL log records are exported to, or an empty string if none is configured.
+func otlpLogsURL() string {
+	if url := os.Getenv(OTLPLogsEndpointEnvVar); url != "" {
+		return url
+	}
+	if endpoint := os.Getenv(OTLPEndpointEnvVar); endpoint != "" {
+		return strings.TrimRight(endpoint, "/") + "/v1/logs"
+	}
+	return ""
+}
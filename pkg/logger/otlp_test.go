@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeExporter records the log records exported to it.
+type fakeExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *fakeExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+
+func (*fakeExporter) Shutdown(context.Context) error { return nil }
+
+func (*fakeExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *fakeExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record{}, e.records...)
+}
+
+func TestOTLPCore(t *testing.T) {
+	t.Parallel()
+	exporter := &fakeExporter{}
+	log := zap.New(newOTLPCore(zapcore.DebugLevel, newOTLPProvider(exporter)))
+
+	log.With(zap.String("component", "proxy")).Warn("upstream slow", zap.Int("attempt", 3), zap.Bool("retry", true))
+	require.NoError(t, log.Sync())
+
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, otellog.SeverityWarn, records[0].Severity())
+	assert.Equal(t, "WARN", records[0].SeverityText())
+	assert.Equal(t, "upstream slow", records[0].Body().AsString())
+	assert.False(t, records[0].Timestamp().IsZero())
+	serviceName, _ := records[0].Resource().Set().Value("service.name")
+	assert.Equal(t, otlpDefaultService, serviceName.AsString())
+
+	assert.Equal(t, "proxy", kv(records[0], "component").AsString())
+	assert.Equal(t, int64(3), kv(records[0], "attempt").AsInt64())
+	assert.True(t, kv(records[0], "retry").AsBool())
+}
+
+func TestOTLPCoreSeverities(t *testing.T) {
+	t.Parallel()
+	exporter := &fakeExporter{}
+	log := zap.New(newOTLPCore(zapcore.DebugLevel, newOTLPProvider(exporter)))
+
+	log.Debug("debug")
+	log.Info("info")
+	log.Error("error")
+	require.NoError(t, log.Sync())
+
+	records := exporter.Records()
+	require.Len(t, records, 3)
+	assert.Equal(t, otellog.SeverityDebug, records[0].Severity())
+	assert.Equal(t, otellog.SeverityInfo, records[1].Severity())
+	assert.Equal(t, otellog.SeverityError, records[2].Severity())
+}
+
+func TestOTLPFromEnv(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	var mu sync.Mutex
+	var bodies [][]byte
+	collector := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, body)
+	}))
+	t.Cleanup(collector.Close)
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+	t.Setenv(OTLPEndpointEnvVar, collector.URL+"/")
+
+	log, err := build()
+	require.NoError(t, err)
+	other, err := build()
+	require.NoError(t, err)
+	log.Info("exported")
+	other.Info("shared")
+	_ = log.Sync()
+
+	provider, err := otlpProviderFromEnv()
+	require.NoError(t, err)
+	assert.Same(t, otlpProviders[collector.URL+"/v1/logs"], provider, "loggers share one exporter per endpoint")
+
+	mu.Lock()
+	defer mu.Unlock()
+	body := bytes.Join(bodies, nil)
+	assert.True(t, bytes.Contains(body, []byte("exported")))
+	assert.True(t, bytes.Contains(body, []byte("shared")), "syncing one logger exports the records of every logger")
+}
+
+func TestOTLPDisabled(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(OTLPEndpointEnvVar, "")
+	t.Setenv(OTLPLogsEndpointEnvVar, "")
+
+	provider, err := otlpProviderFromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+}
+
+// kv returns the value of the named attribute of record, or an empty value if it is absent.
+func kv(record sdklog.Record, key string) otellog.Value {
+	var value otellog.Value
+	record.WalkAttributes(func(attribute otellog.KeyValue) bool {
+		if attribute.Key == key {
+			value = attribute.Value
+			return false
+		}
+		return true
+	})
+	return value
+}
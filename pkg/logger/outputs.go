@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// OutputsEnvVar is the environment variable holding a comma-separated list of outputs entries are
+// written to, replacing the default output. Each output is one of:
+//
+//	file:<path>         a file, created if needed and appended to, which ReopenFiles reopens
+//	net:<host>:<port>   a network collector, reached over TCP, or UDP with ?network=udp
+//	stdout or stderr    the standard output or error of the process
+//
+// Each output may choose its own format with ?format=json, ?format=logfmt or ?format=console, and
+// otherwise uses the format of the default output. For example:
+//
+//	LOG_OUTPUTS=file:/var/log/th.log?format=logfmt,net:collector:514?format=json
+//
+// Entries written to several outputs are counted once per output by Stats.
+const OutputsEnvVar = "LOG_OUTPUTS"
+
+// Formats an output configured through OutputsEnvVar can have.
+const (
+	formatJSON    = "json"
+	formatLogfmt  = "logfmt"
+	formatConsole = "console"
+)
+
+// logOutput is an output configured through OutputsEnvVar.
+type logOutput struct {
+	// kind is "file", "net", "stdout" or "stderr".
+	kind    string
+	target  string
+	network string
+	// format is the format of the output, or "" to use the format of the default output.
+	format string
+}
+
+// logOutputsFromEnv returns the outputs configured in the environment, or nil if there are none.
+func logOutputsFromEnv() ([]logOutput, error) {
+	var outputs []logOutput
+	for _, spec := range strings.Split(os.Getenv(OutputsEnvVar), ",") {
+		if spec = strings.TrimSpace(spec); spec == "" {
+			continue
+		}
+		output, err := parseLogOutput(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s output %q: %w", OutputsEnvVar, spec, err)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func parseLogOutput(spec string) (logOutput, error) {
+	spec, rawQuery, _ := strings.Cut(spec, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return logOutput{}, err
+	}
+	kind, target, _ := strings.Cut(spec, ":")
+	output := logOutput{kind: kind, target: target, network: "tcp", format: query.Get("format")}
+
+	switch output.format {
+	case "", formatJSON, formatLogfmt, formatConsole:
+	default:
+		return logOutput{}, fmt.Errorf("unsupported format %q", output.format)
+	}
+	switch kind {
+	case "file":
+		if target == "" {
+			return logOutput{}, errors.New("missing file path")
+		}
+	case "net":
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			return logOutput{}, err
+		}
+		if network := query.Get("network"); network != "" {
+			if network != "tcp" && network != "udp" {
+				return logOutput{}, fmt.Errorf("unsupported network %q", network)
+			}
+			output.network = network
+		}
+	case "stdout", "stderr":
+	default:
+		return logOutput{}, fmt.Errorf("unsupported output %q", kind)
+	}
+	return output, nil
+}
+
+// open opens the output.
+func (o logOutput) open() (zapcore.WriteSyncer, func(), error) {
+	switch o.kind {
+	case "net":
+		return &netWriteSyncer{network: o.network, address: o.target}, func() {}, nil
+	case "file":
+		file, closeFile, err := openFileOutput(o.target)
+		if err != nil {
+			return nil, nil, err
+		}
+		return file, closeFile, nil
+	default:
+		return zap.Open(o.kind)
+	}
+}
+
+// newOutputsCore returns a core writing entries to every output, each encoded in its own format,
+// along with the file outputs among them.
+func newOutputsCore(outputs []logOutput, config zap.Config) (zapcore.Core, []*reopenableFile, error) {
+	cores := make([]zapcore.Core, 0, len(outputs))
+	closers := make([]func(), 0, len(outputs))
+	var files []*reopenableFile
+	for _, output := range outputs {
+		core, sink, closeOutput, err := output.newCore(config)
+		if err != nil {
+			for _, closeOutput := range closers {
+				closeOutput()
+			}
+			return nil, nil, err
+		}
+		cores = append(cores, core)
+		closers = append(closers, closeOutput)
+		if file, ok := sink.(*reopenableFile); ok {
+			files = append(files, file)
+		}
+	}
+	return zapcore.NewTee(cores...), files, nil
+}
+
+// newCore opens the output and returns the core writing entries to it in its format,
+// along with the opened output and the function closing it.
+func (o logOutput) newCore(config zap.Config) (zapcore.Core, zapcore.WriteSyncer, func(), error) {
+	format := o.format
+	if format == "" {
+		format = config.Encoding
+	}
+	enc, err := newEncoder(format, config.EncoderConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sink, closeOutput, err := o.open()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return newOutputCore(enc, sink, config.Level), sink, closeOutput, nil
+}
+
+// netWriteSyncer writes entries to a network collector. The connection is made on the first write,
+// and made again on the write following a failure, so that a collector which restarts is reconnected to.
+type netWriteSyncer struct {
+	network string
+	address string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (w *netWriteSyncer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		conn, err := net.Dial(w.network, w.address)
+		if err != nil {
+			return 0, err
+		}
+		w.conn = conn
+	}
+	n, err := w.conn.Write(p)
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	return n, err
+}
+
+func (*netWriteSyncer) Sync() error {
+	return nil
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogOutputs(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	logFile := filepath.Join(t.TempDir(), "th.log")
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+	t.Setenv(OutputsEnvVar, "file:"+logFile+"?format=logfmt,net:"+listener.Addr().String()+"?format=json")
+
+	l, err := build()
+	require.NoError(t, err)
+	l.Info("server started", zap.String("server", "fetch"), zap.Int("port", 8080))
+	require.NoError(t, l.Sync())
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	line := string(data)
+	assert.True(t, strings.HasPrefix(line, "level=info ts="), line)
+	assert.Contains(t, line, ` msg="server started" server=fetch port=8080`)
+	assert.True(t, strings.HasSuffix(line, "\n"))
+
+	select {
+	case line := <-received:
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		assert.Equal(t, "info", entry["level"])
+		assert.Equal(t, "server started", entry["msg"])
+		assert.Equal(t, "fetch", entry["server"])
+		assert.Equal(t, float64(8080), entry["port"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("no entry received by the network output")
+	}
+}
+
+func TestParseLogOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec     string
+		expected logOutput
+		errMsg   string
+	}{
+		{spec: "file:/var/log/th.log?format=logfmt", expected: logOutput{
+			kind: "file", target: "/var/log/th.log", network: "tcp", format: formatLogfmt,
+		}},
+		{spec: "net:collector:514?format=json&network=udp", expected: logOutput{
+			kind: "net", target: "collector:514", network: "udp", format: formatJSON,
+		}},
+		{spec: "stderr", expected: logOutput{kind: "stderr", network: "tcp"}},
+		{spec: "file:?format=json", errMsg: "missing file path"},
+		{spec: "net:collector", errMsg: "missing port in address"},
+		{spec: "net:collector:514?network=sctp", errMsg: `unsupported network "sctp"`},
+		{spec: "stdout?format=yaml", errMsg: `unsupported format "yaml"`},
+		{spec: "syslog:local0", errMsg: `unsupported output "syslog"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+			output, err := parseLogOutput(tt.spec)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestLogfmtEncoder(t *testing.T) {
+	t.Parallel()
+
+	enc := newLogfmtEncoder(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder})
+	enc.AddString("component", "api")
+	buf, err := enc.EncodeEntry(zapcore.Entry{Level: zapcore.WarnLevel, Message: "request failed"}, []zapcore.Field{
+		zap.String("path", "/api/v1beta/servers"),
+		zap.String("empty", ""),
+		zap.Strings("tags", []string{"a", "b"}),
+		zap.Any("client", map[string]any{"name": "cursor"}),
+		zap.String("quote", `say "hi"`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `level=warn msg="request failed" component=api path=/api/v1beta/servers empty="" `+
+		`tags="[\"a\",\"b\"]" client.name=cursor quote="say \"hi\""`+"\n", buf.String())
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PoolStats is a snapshot of the usage of a pool of resources, such as connections or workers.
+type PoolStats struct {
+	// InUse is the number of resources currently handed out.
+	InUse int
+	// Idle is the number of resources available for use.
+	Idle int
+	// Waiting is the number of callers waiting for a resource.
+	Waiting int
+}
+
+// LogPoolStats emits an info entry with the stats returned by stats, as in_use, idle and waiting, every
+// interval until the returned function is called, to help diagnose pools running out of resources.
+// The function stops the logging goroutine and waits for it to exit, and may be called more than once.
+func LogPoolStats(l *Logger, stats func() PoolStats, interval time.Duration) (stop func()) {
+	logger := l.Desugar()
+	stopped := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-ticker.C:
+				current := stats()
+				logger.Info("pool stats",
+					zap.Int("in_use", current.InUse),
+					zap.Int("idle", current.Idle),
+					zap.Int("waiting", current.Waiting))
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopped) })
+		<-done
+	}
+}
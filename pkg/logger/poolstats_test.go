@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogPoolStats(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	var calls atomic.Int64
+	stop := LogPoolStats(l, func() PoolStats {
+		n := int(calls.Add(1))
+		return PoolStats{InUse: n, Idle: 10 - n, Waiting: 2}
+	}, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return logs.Len() >= 2
+	}, 5*time.Second, 5*time.Millisecond)
+	stop()
+	stop()
+
+	entries := logs.All()
+	for i, entry := range entries[:2] {
+		assert.Equal(t, zapcore.InfoLevel, entry.Level)
+		assert.Equal(t, "pool stats", entry.Message)
+		assert.Equal(t, map[string]any{
+			"in_use":  int64(i + 1),
+			"idle":    int64(10 - i - 1),
+			"waiting": int64(2),
+		}, entry.ContextMap())
+	}
+
+	// No entries are emitted once stopped.
+	emitted := logs.Len()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, emitted, logs.Len())
+	assert.Equal(t, int64(emitted), calls.Load())
+}
@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithPrefix returns a child Logger which prepends prefix to the message of every entry, e.g.
+// "[migration] ", so that the entries of a scope can be told apart when reading messages alone.
+// Prefixes compose when chained, outermost first: l.WithPrefix("[a] ").WithPrefix("[b] ") logs
+// "[a] [b] message". Unlike With, the prefix is part of the message rather than a field.
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	return &Logger{SugaredLogger: l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &prefixCore{Core: core, prefix: prefix}
+	}))}
+}
+
+// prefixCore prepends a prefix to the message of every entry.
+type prefixCore struct {
+	zapcore.Core
+	prefix string
+}
+
+func (c *prefixCore) With(fields []zapcore.Field) zapcore.Core {
+	return &prefixCore{Core: c.Core.With(fields), prefix: c.prefix}
+}
+
+func (c *prefixCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *prefixCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.prefix + ent.Message
+	return c.Core.Write(ent, fields)
+}
@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithPrefix(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	migration := l.WithPrefix("[migration] ")
+	migration.Infow("moving servers", "count", 3)
+	migration.WithPrefix("[groups] ").With("group", "default").Warn("group created")
+	l.Info("unprefixed")
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "[migration] moving servers", entries[0].Message)
+	assert.Equal(t, map[string]any{"count": int64(3)}, entries[0].ContextMap(), "the prefix is not a field")
+	assert.Equal(t, "[migration] [groups] group created", entries[1].Message)
+	assert.Equal(t, "default", entries[1].ContextMap()["group"])
+	assert.Equal(t, "unprefixed", entries[2].Message)
+}
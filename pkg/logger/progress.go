@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// progressThrottle tracks when progress entries were last emitted for each key.
+var progressThrottle = newThrottle()
+
+// Progress emits an info entry for the given key at most once per interval, so that a
+// long-running loop can call it on every iteration and still produce a steady heartbeat.
+// The first call for a key always emits. Keys are shared by all loggers in the process.
+func (l *Logger) Progress(key, msg string, every time.Duration, keysAndValues ...any) {
+	if !progressThrottle.allow(key, now(), every) {
+		return
+	}
+	l.WithOptions(zap.AddCallerSkip(1)).Infow(msg, keysAndValues...)
+}
+
+// throttle allows one event per key within an interval.
+type throttle struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func newThrottle() *throttle {
+	return &throttle{last: map[string]time.Time{}}
+}
+
+// allow reports whether an event for key may happen at now, recording it if so.
+func (t *throttle) allow(key string, now time.Time, every time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if last, ok := t.last[key]; ok && now.Sub(last) < every {
+		return false
+	}
+	t.last[key] = now
+	return true
+}
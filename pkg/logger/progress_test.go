@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestProgress(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	const every = 50 * time.Millisecond
+	start := time.Now()
+	for i := 0; time.Since(start) < 10*every; i++ {
+		l.Progress("TestProgress", "copying layers", every, "iteration", i)
+		time.Sleep(time.Millisecond)
+	}
+
+	entries := logs.All()
+	// One entry per elapsed interval, allowing for scheduling jitter.
+	assert.GreaterOrEqual(t, len(entries), 8)
+	assert.LessOrEqual(t, len(entries), 11)
+	require.NotEmpty(t, entries)
+	assert.Equal(t, int64(0), entries[0].ContextMap()["iteration"], "the first call must always emit")
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "copying layers", entries[0].Message)
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	throttle := newThrottle()
+	now := time.Now()
+	every := time.Second
+
+	assert.True(t, throttle.allow("a", now, every), "first event is always allowed")
+	assert.False(t, throttle.allow("a", now.Add(999*time.Millisecond), every))
+	assert.True(t, throttle.allow("b", now, every), "keys are throttled independently")
+	assert.True(t, throttle.allow("a", now.Add(every), every))
+	assert.False(t, throttle.allow("a", now.Add(every+time.Millisecond), every))
+}
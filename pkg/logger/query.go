@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// QueryLogger logs database queries, surfacing those slower than a threshold.
+// Only the parameterized SQL is logged: argument values are never included,
+// as they may hold credentials or personal data.
+type QueryLogger struct {
+	logger             *Logger
+	slowQueryThreshold time.Duration
+}
+
+// NewQueryLogger creates a QueryLogger which logs queries taking at least slowQueryThreshold
+// at warn level, and all other queries at debug level. A zero threshold disables slow query detection.
+func NewQueryLogger(l *Logger, slowQueryThreshold time.Duration) *QueryLogger {
+	return &QueryLogger{logger: l, slowQueryThreshold: slowQueryThreshold}
+}
+
+// Start begins timing query, and returns a function to call with the query's error once it completes:
+//
+//	done := queries.Start("SELECT name FROM servers WHERE id = ?", id)
+//	rows, err := db.QueryContext(ctx, "SELECT name FROM servers WHERE id = ?", id)
+//	done(err)
+func (q *QueryLogger) Start(query string, args ...any) func(err error) {
+	start := now()
+	return func(err error) {
+		q.LogQuery(query, args, since(start), err)
+	}
+}
+
+// LogQuery logs a query which completed after duration, with its error if it failed.
+// Only the number of arguments is logged, never their values.
+func (q *QueryLogger) LogQuery(query string, args []any, duration time.Duration, err error) {
+	fields := []any{
+		zap.String("query", query),
+		zap.Duration("duration", duration),
+		zap.Int("arg_count", len(args)),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	if q.slowQueryThreshold > 0 && duration >= q.slowQueryThreshold {
+		q.logger.Warnw("slow query", append(fields, zap.Duration("slow_query_threshold", q.slowQueryThreshold))...)
+		return
+	}
+	q.logger.Debugw("query", fields...)
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestQueryLogger(t *testing.T) {
+	t.Parallel()
+
+	const query = "SELECT name FROM servers WHERE id = ? AND token = ?"
+	args := []any{42, "super-secret-token"}
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		level    zapcore.Level
+		message  string
+	}{
+		{"FastQuery", 5 * time.Millisecond, zapcore.DebugLevel, "query"},
+		{"SlowQuery", 750 * time.Millisecond, zapcore.WarnLevel, "slow query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l, logs := newObservedLogger(zapcore.DebugLevel)
+
+			NewQueryLogger(l, 500*time.Millisecond).LogQuery(query, args, tt.duration, nil)
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.level, entries[0].Level)
+			assert.Equal(t, tt.message, entries[0].Message)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, query, fields["query"])
+			assert.Equal(t, tt.duration, fields["duration"])
+			assert.Equal(t, int64(2), fields["arg_count"])
+			for _, value := range fields {
+				assert.NotEqual(t, "super-secret-token", value)
+			}
+		})
+	}
+}
+
+func TestQueryLoggerError(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	done := NewQueryLogger(l, time.Hour).Start("DELETE FROM servers WHERE id = ?", 42)
+	done(errors.New("no such table: servers"))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "no such table: servers", entries[0].ContextMap()["error"])
+}
+
+func TestQueryLoggerNoThreshold(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	NewQueryLogger(l, 0).LogQuery("SELECT 1", nil, time.Minute, nil)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+}
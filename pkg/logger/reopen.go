@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ReopenFiles flushes the entries l has buffered, then reopens every file output configured through
+// OutputsEnvVar, so that entries are written to a new file once the previous one has been rotated and
+// so that tests can read everything logged so far. Syncing outputs which cannot be synced, such as a
+// terminal, is not an error. All failures are returned together.
+func (l *Logger) ReopenFiles() error {
+	var errs []error
+	if err := l.Sync(); err != nil && !isUnsyncable(err) {
+		errs = append(errs, fmt.Errorf("failed to flush logger: %w", err))
+	}
+
+	for _, file := range fileOutputsOf(l.Desugar().Core()) {
+		if err := file.reopen(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fileOutputsCore is the outermost core of loggers writing to file outputs, which records them for ReopenFiles.
+type fileOutputsCore struct {
+	zapcore.Core
+	files []*reopenableFile
+}
+
+func (c *fileOutputsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &fileOutputsCore{Core: c.Core.With(fields), files: c.files}
+}
+
+// fileOutputsOf returns the file outputs written to by core, looking through the cores wrapping it
+// which can be unwrapped, such as those added by ForContext.
+func fileOutputsOf(core zapcore.Core) []*reopenableFile {
+	for {
+		switch c := core.(type) {
+		case *fileOutputsCore:
+			return c.files
+		case interface{ Unwrap() zapcore.Core }:
+			core = c.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// reopenableFile is a file output which is appended to, and which can be reopened.
+type reopenableFile struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// openFileOutput opens the file at path as an output, creating it if needed.
+func openFileOutput(path string) (*reopenableFile, func(), error) {
+	file, err := openLogFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	output := &reopenableFile{path: path, file: file}
+	return output, output.close, nil
+}
+
+func (f *reopenableFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Write(p)
+}
+
+func (f *reopenableFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Sync()
+}
+
+// reopen syncs and closes the file, then opens the file at its path again, which is a new file if
+// the previous one has been moved away. If the file cannot be opened again, the previous one is kept.
+func (f *reopenableFile) reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := openLogFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to reopen log file %s: %w", f.path, err)
+	}
+	previous := f.file
+	f.file = file
+	if err := previous.Sync(); err != nil && !isUnsyncable(err) {
+		_ = previous.Close()
+		return fmt.Errorf("failed to sync log file %s: %w", f.path, err)
+	}
+	return previous.Close()
+}
+
+// close closes the file.
+func (f *reopenableFile) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_ = f.file.Close()
+}
+
+func openLogFile(path string) (*os.File, error) {
+	// #nosec G304: The path of the log file is configured by the user.
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReopenFiles(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	logFile := filepath.Join(t.TempDir(), "th.log")
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+	t.Setenv(OutputsEnvVar, "file:"+logFile+"?format=logfmt")
+	// Entries are buffered, so only a flush guarantees they have been written.
+	t.Setenv(AsyncBufferSizeEnvVar, "16")
+
+	l := NewLogger()
+	l.Info("first entry")
+	l.Info("second entry")
+	require.NoError(t, l.ReopenFiles())
+
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `msg="first entry"`)
+	assert.Contains(t, lines[1], `msg="second entry"`)
+
+	// Once the file has been rotated, entries are written to a new file at the same path.
+	require.NoError(t, os.Rename(logFile, logFile+".1"))
+	require.NoError(t, l.ReopenFiles())
+	l.Info("third entry")
+	require.NoError(t, l.ReopenFiles())
+
+	data, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `msg="third entry"`)
+	assert.NotContains(t, string(data), "first entry")
+	rotated, err := os.ReadFile(logFile + ".1")
+	require.NoError(t, err)
+	assert.NotContains(t, string(rotated), "third entry")
+}
@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"runtime"
+
+	"go.uber.org/zap"
+)
+
+// LogResourceUsage emits an info entry with a snapshot of the process resource usage:
+// the goroutine count, heap allocation, number of completed GC cycles and, where the
+// platform exposes it, the number of open file descriptors. It is cheap enough to be
+// called by the caller on a timer.
+func LogResourceUsage(l *Logger) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	keysAndValues := []any{
+		"goroutines", runtime.NumGoroutine(),
+		"heap_alloc_bytes", stats.HeapAlloc,
+		"heap_objects", stats.HeapObjects,
+		"gc_count", stats.NumGC,
+	}
+	if fds, ok := openFileDescriptors(); ok {
+		keysAndValues = append(keysAndValues, "open_fds", fds)
+	}
+
+	l.WithOptions(zap.AddCallerSkip(1)).Infow("resource usage", keysAndValues...)
+}
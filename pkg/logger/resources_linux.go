@@ -0,0 +1,16 @@
+//go:build linux
+// +build linux
+
+package logger
+
+import "os"
+
+// openFileDescriptors returns the number of file descriptors held open by the process.
+func openFileDescriptors() (int, bool) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, false
+	}
+	// Reading the directory itself holds one descriptor open, which is not counted.
+	return len(entries) - 1, true
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package logger
+
+// openFileDescriptors is not supported on this platform.
+func openFileDescriptors() (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogResourceUsage(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	runtime.GC()
+	LogResourceUsage(l)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "resource usage", entries[0].Message)
+
+	fields := entries[0].ContextMap()
+	assert.GreaterOrEqual(t, fields["goroutines"], int64(1))
+	assert.Greater(t, fields["heap_alloc_bytes"], uint64(0))
+	assert.Greater(t, fields["heap_objects"], uint64(0))
+	assert.GreaterOrEqual(t, fields["gc_count"], uint32(1))
+	if runtime.GOOS == "linux" {
+		// At least stdin, stdout and stderr are open.
+		assert.GreaterOrEqual(t, fields["open_fds"], int64(3))
+	}
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RingBufferSizeEnvVar is the environment variable holding the number of recent
+// entries kept in memory and served by RecentLogsHandler. Zero or unset disables the buffer.
+const RingBufferSizeEnvVar = "LOG_RINGBUFFER_SIZE"
+
+// recentLogs is the ring buffer created by Initialize, if enabled.
+// Loggers built afterwards, including those returned by NewLogger, store their entries in it too.
+var recentLogs atomic.Pointer[ringBuffer]
+
+// RecentLogsHandler returns an http.Handler which responds with the most recent log
+// entries as a JSON array, oldest first. When the ring buffer is disabled the array is empty.
+func RecentLogsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := recentLogs.Load()
+		if buffer == nil {
+			buffer = newRingBuffer(0)
+		}
+		buffer.ServeHTTP(w, r)
+	})
+}
+
+// ringBuffer holds the most recent encoded log entries, overwriting the oldest once full.
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []json.RawMessage
+	next    int
+	full    bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{entries: make([]json.RawMessage, size)}
+}
+
+func (r *ringBuffer) add(entry json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) == 0 {
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns a copy of the buffered entries, oldest first.
+func (r *ringBuffer) snapshot() []json.RawMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]json.RawMessage{}, r.entries[:r.next]...)
+	}
+	return append(append([]json.RawMessage{}, r.entries[r.next:]...), r.entries[:r.next]...)
+}
+
+func (r *ringBuffer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.snapshot()); err != nil {
+		http.Error(w, "failed to encode recent logs", http.StatusInternalServerError)
+	}
+}
+
+// ringBufferCore encodes entries as JSON and stores them in a ringBuffer.
+type ringBufferCore struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	buffer *ringBuffer
+}
+
+func newRingBufferCore(enabler zapcore.LevelEnabler, buffer *ringBuffer) zapcore.Core {
+	return &ringBufferCore{
+		LevelEnabler: enabler,
+		enc:          zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		buffer:       buffer,
+	}
+}
+
+func (c *ringBufferCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	return &ringBufferCore{LevelEnabler: c.LevelEnabler, enc: enc, buffer: c.buffer}
+}
+
+func (c *ringBufferCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *ringBufferCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	c.buffer.add(bytes.TrimSpace(append([]byte{}, buf.Bytes()...)))
+	buf.Free()
+	return nil
+}
+
+func (*ringBufferCore) Sync() error {
+	return nil
+}
+
+// ringBufferSize returns the ring buffer size configured in the environment, or zero if disabled.
+func ringBufferSize() int {
+	size, err := strconv.Atoi(os.Getenv(RingBufferSizeEnvVar))
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func fetchRecentLogs(t *testing.T, handler http.Handler) []map[string]any {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	return entries
+}
+
+func TestRingBufferCore(t *testing.T) {
+	t.Parallel()
+
+	buffer := newRingBuffer(3)
+	l := zap.New(newRingBufferCore(zapcore.DebugLevel, buffer)).Sugar()
+
+	for i := range 5 {
+		l.Infow(fmt.Sprintf("message %d", i), "index", i)
+	}
+
+	entries := fetchRecentLogs(t, buffer)
+	require.Len(t, entries, 3)
+	for i, entry := range entries {
+		assert.Equal(t, fmt.Sprintf("message %d", i+2), entry["msg"])
+		assert.Equal(t, float64(i+2), entry["index"])
+	}
+}
+
+func TestRingBufferPartiallyFilled(t *testing.T) {
+	t.Parallel()
+
+	buffer := newRingBuffer(10)
+	l := zap.New(newRingBufferCore(zapcore.DebugLevel, buffer)).With(zap.String("component", "test"))
+	l.Info("first")
+	l.Debug("second")
+
+	entries := fetchRecentLogs(t, buffer)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0]["msg"])
+	assert.Equal(t, "test", entries[0]["component"])
+	assert.Equal(t, "second", entries[1]["msg"])
+}
+
+func TestRingBufferConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	buffer := newRingBuffer(50)
+	l := zap.New(newRingBufferCore(zapcore.DebugLevel, buffer))
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 20 {
+				l.Info("concurrent", zap.Int("goroutine", i), zap.Int("iteration", j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, fetchRecentLogs(t, buffer), 50)
+}
+
+func TestRecentLogsHandler(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	t.Run("Enabled", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(RingBufferSizeEnvVar, "3")
+		Initialize()
+		for i := range 4 {
+			Infof("message %d", i)
+		}
+		NewLogger().Info("from another logger")
+
+		t.Setenv(RingBufferSizeEnvVar, "")
+		NewLogger().Info("built while disabled")
+
+		entries := fetchRecentLogs(t, RecentLogsHandler())
+		require.Len(t, entries, 3, "building other loggers does not replace the buffer")
+		assert.Equal(t, "message 3", entries[0]["msg"])
+		assert.Equal(t, "from another logger", entries[1]["msg"])
+		assert.Equal(t, "built while disabled", entries[2]["msg"])
+	})
+
+	t.Run("Disabled", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(RingBufferSizeEnvVar, "")
+		Initialize()
+		Info("not buffered")
+
+		assert.Empty(t, fetchRecentLogs(t, RecentLogsHandler()))
+	})
+}
@@ -0,0 +1,24 @@
+package logger
+
+import "os"
+
+const (
+	// RunIDSourceEnvVar is the environment variable naming the environment variable which holds
+	// the ID of the CI job or batch run being logged. Defaults to DefaultRunIDEnvVar.
+	RunIDSourceEnvVar = "LOG_RUN_ID_ENV"
+
+	// DefaultRunIDEnvVar is the environment variable holding the run ID unless RunIDSourceEnvVar is set.
+	DefaultRunIDEnvVar = "TOOLHIVE_RUN_ID"
+
+	// RunIDKey is the key of the field holding the run ID.
+	RunIDKey = "run_id"
+)
+
+// runID returns the run ID to add to every entry, or an empty string if there is none.
+func runID() string {
+	envVar := os.Getenv(RunIDSourceEnvVar)
+	if envVar == "" {
+		envVar = DefaultRunIDEnvVar
+	}
+	return os.Getenv(envVar)
+}
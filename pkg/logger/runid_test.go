@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunID(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Run("DefaultEnvVar", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(RunIDSourceEnvVar, "")
+		t.Setenv(DefaultRunIDEnvVar, "build-1234")
+
+		entry := logStructuredEntry(t)
+		assert.Equal(t, "build-1234", entry[RunIDKey])
+	})
+
+	t.Run("ConfiguredEnvVar", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(RunIDSourceEnvVar, "GITHUB_RUN_ID")
+		t.Setenv("GITHUB_RUN_ID", "9876543210")
+		t.Setenv(DefaultRunIDEnvVar, "build-1234")
+
+		entry := logStructuredEntry(t)
+		assert.Equal(t, "9876543210", entry[RunIDKey])
+	})
+
+	t.Run("Unset", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(RunIDSourceEnvVar, "")
+		t.Setenv(DefaultRunIDEnvVar, "")
+
+		entry := logStructuredEntry(t)
+		assert.NotContains(t, entry, RunIDKey)
+	})
+
+	t.Run("ConfiguredEnvVarEmpty", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(RunIDSourceEnvVar, "GITHUB_RUN_ID")
+		t.Setenv("GITHUB_RUN_ID", "")
+		t.Setenv(DefaultRunIDEnvVar, "build-1234")
+
+		entry := logStructuredEntry(t)
+		assert.NotContains(t, entry, RunIDKey)
+	})
+}
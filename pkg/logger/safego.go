@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"os"
+	"runtime/debug"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// SafeGoRepanicEnvVar is the environment variable which, when set to true, makes SafeGo panic
+// again once a recovered panic has been logged, crashing the process as a bare goroutine would.
+const SafeGoRepanicEnvVar = "LOG_SAFEGO_REPANIC"
+
+// panicStackKey is the key of the field holding the stack of a goroutine whose panic SafeGo recovered.
+const panicStackKey = "panic_stack"
+
+// SafeGo runs fn in a new goroutine. If fn panics, the panic value and the stack of the panicking
+// goroutine are logged through l at error level, the stack under the panic_stack key so that it
+// does not clash with the stacktrace zap adds to error entries, and the process keeps running
+// unless SafeGoRepanicEnvVar is set.
+func SafeGo(l *Logger, fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.Desugar().Error("recovered panic in goroutine",
+					zap.Any("panic", r),
+					zap.String(panicStackKey, string(debug.Stack())))
+				if repanic, _ := strconv.ParseBool(os.Getenv(SafeGoRepanicEnvVar)); repanic {
+					panic(r)
+				}
+			}
+		}()
+		fn()
+	}()
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSafeGo(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	SafeGo(l, func() {
+		panic("connection pool exhausted")
+	})
+
+	require.Eventually(t, func() bool { return logs.Len() == 1 }, 5*time.Second, 10*time.Millisecond)
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.ErrorLevel, entry.Level)
+	assert.Equal(t, "recovered panic in goroutine", entry.Message)
+	fields := entry.ContextMap()
+	assert.Equal(t, "connection pool exhausted", fields["panic"])
+	assert.Contains(t, fields[panicStackKey], "TestSafeGo")
+	assert.NotContains(t, fields, "stacktrace", "the stack does not clash with the one zap adds")
+
+	// The process survives, and SafeGo keeps running functions.
+	done := make(chan struct{})
+	SafeGo(l, func() { close(done) })
+	<-done
+}
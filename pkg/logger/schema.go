@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	// SchemaVersionEnvVar is the environment variable which, when set to true, adds the version of
+	// the log schema to every entry, so that consumers parsing the logs can branch on it.
+	SchemaVersionEnvVar = "LOG_SCHEMA_VERSION"
+
+	// SchemaVersionKey is the key of the field holding the version of the log schema.
+	SchemaVersionKey = "log_schema"
+
+	// SchemaVersion is the version of the log schema entries follow. It is increased whenever
+	// the fields of entries change in a way consumers must account for.
+	SchemaVersion = "1"
+)
+
+// schemaVersion returns the version of the log schema to add to every entry, or an empty string
+// if it is not enabled.
+func schemaVersion() string {
+	if enabled, _ := strconv.ParseBool(os.Getenv(SchemaVersionEnvVar)); !enabled {
+		return ""
+	}
+	return SchemaVersion
+}
@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaVersion(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Run("Enabled", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(SchemaVersionEnvVar, "true")
+
+		entry := logStructuredEntry(t)
+		assert.Equal(t, SchemaVersion, entry[SchemaVersionKey])
+		assert.Equal(t, "1", entry["log_schema"])
+	})
+
+	t.Run("Disabled", func(t *testing.T) { //nolint:paralleltest // Uses environment variables
+		t.Setenv(SchemaVersionEnvVar, "")
+
+		entry := logStructuredEntry(t)
+		assert.NotContains(t, entry, SchemaVersionKey)
+	})
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// SeverityNumberEnvVar is the environment variable which, when set to true, adds the numeric
+	// severity of every entry alongside its level, for ingestion systems which sort or filter by
+	// number. Severities are the OpenTelemetry severity numbers: 5 for debug, 9 for info, 13 for warn,
+	// 17 for error, and 21 to 23 for dpanic, panic and fatal.
+	SeverityNumberEnvVar = "LOG_SEVERITY_NUMBER"
+	// SeverityKey is the field holding the numeric severity of an entry.
+	SeverityKey = "severity"
+)
+
+// severityCore adds the OpenTelemetry severity number of its level to every entry.
+type severityCore struct {
+	zapcore.Core
+}
+
+func newSeverityCore(core zapcore.Core) zapcore.Core {
+	return &severityCore{Core: core}
+}
+
+func (c *severityCore) With(fields []zapcore.Field) zapcore.Core {
+	return &severityCore{Core: c.Core.With(fields)}
+}
+
+func (c *severityCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *severityCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	severity := zap.Int32(SeverityKey, int32(otlpSeverities[ent.Level]))
+	return c.Core.Write(ent, append(fields[:len(fields):len(fields)], severity))
+}
+
+// severityNumberEnabled reports whether numeric severities have been enabled through SeverityNumberEnvVar.
+func severityNumberEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(SeverityNumberEnvVar))
+	return err == nil && enabled
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSeverityCore(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(newSeverityCore(core)).With(zap.String("component", "proxy"))
+
+	tests := []struct {
+		level    zapcore.Level
+		severity int32
+	}{
+		{zapcore.DebugLevel, 5},
+		{zapcore.InfoLevel, 9},
+		{zapcore.WarnLevel, 13},
+		{zapcore.ErrorLevel, 17},
+		{zapcore.DPanicLevel, 21},
+	}
+	for _, tt := range tests {
+		log.Log(tt.level, "message")
+	}
+
+	entries := logs.All()
+	require.Len(t, entries, len(tests))
+	for i, tt := range tests {
+		assert.Equal(t, tt.level, entries[i].Level)
+		assert.Equal(t, map[string]any{"component": "proxy", SeverityKey: tt.severity}, entries[i].ContextMap(),
+			tt.level.String())
+	}
+}
+
+func TestSeverityNumberFromEnv(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv(SeverityNumberEnvVar, "true")
+	entry := logStructuredEntry(t)
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, float64(9), entry[SeverityKey])
+
+	t.Setenv(SeverityNumberEnvVar, "false")
+	entry = logStructuredEntry(t)
+	assert.NotContains(t, entry, SeverityKey)
+}
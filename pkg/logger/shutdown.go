@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"syscall"
+)
+
+// ShutdownFlusher runs the shutdown hooks of a process's components, and flushes a Logger
+// as the very last step so that entries logged while shutting down are not lost.
+type ShutdownFlusher struct {
+	logger *Logger
+
+	mu    sync.Mutex
+	hooks []shutdownHook
+	once  sync.Once
+	err   error
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// NewShutdownFlusher creates a ShutdownFlusher which flushes l once every hook has completed.
+func NewShutdownFlusher(l *Logger) *ShutdownFlusher {
+	return &ShutdownFlusher{logger: l}
+}
+
+// Register adds a hook run on Shutdown. Hooks run one at a time in reverse registration order,
+// like deferred calls, so that components registered later, which may depend on earlier ones,
+// are stopped first. Hooks registered once Shutdown has started are not run.
+func (s *ShutdownFlusher) Register(name string, hook func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, shutdownHook{name: name, fn: hook})
+}
+
+// Shutdown runs every registered hook, logging any failure, and then syncs the logger.
+// A failing hook does not prevent the remaining hooks from running, nor the logger from
+// being synced. The joined errors are returned. Calling Shutdown again returns the same
+// result without running anything.
+func (s *ShutdownFlusher) Shutdown(ctx context.Context) error {
+	s.once.Do(func() {
+		s.mu.Lock()
+		hooks := s.hooks
+		s.hooks = nil
+		s.mu.Unlock()
+
+		var errs []error
+		for i := len(hooks) - 1; i >= 0; i-- {
+			if err := hooks[i].fn(ctx); err != nil {
+				s.logger.Errorw("shutdown hook failed", "hook", hooks[i].name, "error", err)
+				errs = append(errs, fmt.Errorf("%s: %w", hooks[i].name, err))
+			}
+		}
+
+		if err := s.logger.Sync(); err != nil && !isUnsyncable(err) {
+			errs = append(errs, fmt.Errorf("failed to flush logs: %w", err))
+		}
+		s.err = errors.Join(errs...)
+	})
+	return s.err
+}
+
+// isUnsyncable reports whether err was returned by syncing an output that cannot be synced,
+// such as a terminal or pipe, which is expected when logging to stdout or stderr.
+func isUnsyncable(err error) bool {
+	return errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTTY)
+}
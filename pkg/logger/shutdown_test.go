@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// callRecorder records the order in which calls happen.
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *callRecorder) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// syncRecordingCore records when it is synced.
+type syncRecordingCore struct {
+	zapcore.Core
+	recorder *callRecorder
+}
+
+func (c *syncRecordingCore) Sync() error {
+	c.recorder.record("logger.Sync")
+	return c.Core.Sync()
+}
+
+func newShutdownTestLogger(recorder *callRecorder) (*Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return &Logger{SugaredLogger: zap.New(&syncRecordingCore{Core: core, recorder: recorder}).Sugar()}, logs
+}
+
+func TestShutdownFlusher(t *testing.T) {
+	t.Parallel()
+	recorder := &callRecorder{}
+	l, logs := newShutdownTestLogger(recorder)
+	flusher := NewShutdownFlusher(l)
+
+	flusher.Register("database", func(context.Context) error {
+		recorder.record("database")
+		return nil
+	})
+	flusher.Register("server", func(context.Context) error {
+		recorder.record("server")
+		l.Info("server stopped")
+		return nil
+	})
+
+	require.NoError(t, flusher.Shutdown(context.Background()))
+	assert.Equal(t, []string{"server", "database", "logger.Sync"}, recorder.calls)
+	assert.Equal(t, 1, logs.FilterMessage("server stopped").Len())
+
+	// Shutdown only runs once.
+	require.NoError(t, flusher.Shutdown(context.Background()))
+	assert.Len(t, recorder.calls, 3)
+}
+
+func TestShutdownFlusherHookFailure(t *testing.T) {
+	t.Parallel()
+	recorder := &callRecorder{}
+	l, logs := newShutdownTestLogger(recorder)
+	flusher := NewShutdownFlusher(l)
+
+	flusher.Register("cache", func(context.Context) error {
+		recorder.record("cache")
+		return nil
+	})
+	flusher.Register("server", func(context.Context) error {
+		recorder.record("server")
+		return errors.New("listener already closed")
+	})
+
+	err := flusher.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server: listener already closed")
+	assert.Equal(t, []string{"server", "cache", "logger.Sync"}, recorder.calls)
+
+	failures := logs.FilterMessage("shutdown hook failed").All()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "server", failures[0].ContextMap()["hook"])
+}
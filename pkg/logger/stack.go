@@ -0,0 +1,19 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+)
+
+// StackKey is the key of the field holding the stack trace logged by LogStack.
+const StackKey = "stack"
+
+// LogStack emits an info entry with msg and the stack trace of the calling goroutine as StackKey,
+// for ad-hoc debugging of how a code path was reached. The stack trace is added whatever the
+// level from which the logger adds stack traces to entries, and starts at the caller.
+func (l *Logger) LogStack(msg string) {
+	logger := l.Desugar()
+	if !logger.Core().Enabled(zap.InfoLevel) {
+		return
+	}
+	logger.WithOptions(zap.AddCallerSkip(1)).Info(msg, zap.StackSkip(StackKey, 1))
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogStack(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	l.LogStack("reached reconcile")
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, "reached reconcile", entries[0].Message)
+	assert.Empty(t, entries[0].Stack, "the stack trace is a field, not the entry's own stack trace")
+	stack, ok := entries[0].ContextMap()[StackKey].(string)
+	require.True(t, ok)
+	assert.Contains(t, stack, "logger.TestLogStack")
+	assert.NotContains(t, stack, "logger.(*Logger).LogStack", "the stack trace starts at the caller")
+}
+
+func TestLogStackDisabled(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.WarnLevel)
+
+	l.LogStack("reached reconcile")
+
+	assert.Zero(t, logs.Len())
+}
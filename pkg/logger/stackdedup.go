@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"hash/fnv"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// StacktraceDedupWindowEnvVar is the environment variable holding the window, e.g. "1m",
+	// within which a recurring stack trace is only emitted once. Zero or unset disables deduplication.
+	StacktraceDedupWindowEnvVar = "LOG_STACKTRACE_DEDUP_WINDOW"
+	// StackRefKey is the field holding the hash of an entry's stack trace, which identifies the
+	// entry carrying the full trace when the trace itself has been omitted.
+	StackRefKey = "stack_ref"
+)
+
+// stackDedupCore omits stack traces which have already been emitted within the window,
+// replacing them with a reference to the entry which carried the full trace.
+type stackDedupCore struct {
+	zapcore.Core
+	window time.Duration
+	seen   *throttle
+}
+
+func newStackDedupCore(core zapcore.Core, window time.Duration) zapcore.Core {
+	return &stackDedupCore{Core: core, window: window, seen: newThrottle()}
+}
+
+func (c *stackDedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &stackDedupCore{Core: c.Core.With(fields), window: c.window, seen: c.seen}
+}
+
+func (c *stackDedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *stackDedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Stack == "" {
+		return c.Core.Write(ent, fields)
+	}
+
+	ref := stackHash(ent.Stack)
+	if !c.seen.allow(ref, ent.Time, c.window) {
+		ent.Stack = ""
+	}
+	return c.Core.Write(ent, append(fields[:len(fields):len(fields)], zap.String(StackRefKey, ref)))
+}
+
+// stackHash returns a short hash identifying a stack trace.
+func stackHash(stack string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(stack))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// stacktraceDedupWindow returns the deduplication window configured in the environment, or zero if disabled.
+func stacktraceDedupWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv(StacktraceDedupWindowEnvVar))
+	if err != nil || window < 0 {
+		return 0
+	}
+	return window
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newStackDedupTestLogger(window time.Duration) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(newStackDedupCore(core, window), zap.AddStacktrace(zapcore.ErrorLevel)), logs
+}
+
+// failRepeatedly logs the same error from the same call site, and so with the same stack.
+func failRepeatedly(log *zap.Logger, times int) {
+	for range times {
+		log.Error("operation failed", zap.Error(errors.New("connection refused")))
+	}
+}
+
+func TestStackDedupCore(t *testing.T) {
+	t.Parallel()
+	log, logs := newStackDedupTestLogger(time.Hour)
+
+	failRepeatedly(log, 3)
+	log.Info("no stack")
+
+	entries := logs.All()
+	require.Len(t, entries, 4)
+	require.NotEmpty(t, entries[0].Stack)
+	ref := entries[0].ContextMap()[StackRefKey]
+	assert.Equal(t, stackHash(entries[0].Stack), ref)
+	for _, entry := range entries[1:3] {
+		assert.Equal(t, "operation failed", entry.Message)
+		assert.Equal(t, "connection refused", entry.ContextMap()["error"])
+		assert.Empty(t, entry.Stack)
+		assert.Equal(t, ref, entry.ContextMap()[StackRefKey])
+	}
+	assert.NotContains(t, entries[3].ContextMap(), StackRefKey)
+}
+
+func TestStackDedupCoreWindow(t *testing.T) {
+	t.Parallel()
+	log, logs := newStackDedupTestLogger(time.Nanosecond)
+
+	failRepeatedly(log, 1)
+	time.Sleep(time.Millisecond)
+	failRepeatedly(log, 1)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.NotEmpty(t, entries[0].Stack)
+	assert.NotEmpty(t, entries[1].Stack, "the stack is emitted again once the window has passed")
+}
+
+func TestStackDedupCoreDistinctStacks(t *testing.T) {
+	t.Parallel()
+	log, logs := newStackDedupTestLogger(time.Hour)
+
+	log.Error("first site")
+	log.Error("second site")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.NotEmpty(t, entries[0].Stack)
+	assert.NotEmpty(t, entries[1].Stack)
+	assert.NotEqual(t, entries[0].ContextMap()[StackRefKey], entries[1].ContextMap()[StackRefKey])
+}
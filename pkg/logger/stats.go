@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// LoggerStats counts what happened to the entries passed to loggers built by this package.
+//
+//nolint:revive // LoggerStats reads better than Stats alongside the Stats function
+type LoggerStats struct {
+	// Emitted is the number of entries written to the log output.
+	Emitted uint64
+	// SampledOut is the number of entries discarded by sampling.
+	SampledOut uint64
+	// Dropped is the number of entries discarded because the async buffer was full.
+	Dropped uint64
+	// EncodeErrors is the number of entries which could not be encoded.
+	EncodeErrors uint64
+}
+
+var (
+	emittedEntries    atomic.Uint64
+	sampledOutEntries atomic.Uint64
+	droppedEntries    atomic.Uint64
+	encodeErrors      atomic.Uint64
+)
+
+// Stats returns the counters accumulated by every logger built by this package since the process started.
+func Stats() LoggerStats {
+	return LoggerStats{
+		Emitted:      emittedEntries.Load(),
+		SampledOut:   sampledOutEntries.Load(),
+		Dropped:      droppedEntries.Load(),
+		EncodeErrors: encodeErrors.Load(),
+	}
+}
+
+// NewStatsCollector returns a Prometheus collector which exports the counters reported by Stats.
+func NewStatsCollector() prometheus.Collector {
+	return &statsCollector{
+		emitted: prometheus.NewDesc("toolhive_logger_entries_emitted_total",
+			"Number of log entries written to the log output.", nil, nil),
+		sampledOut: prometheus.NewDesc("toolhive_logger_entries_sampled_out_total",
+			"Number of log entries discarded by sampling.", nil, nil),
+		dropped: prometheus.NewDesc("toolhive_logger_entries_dropped_total",
+			"Number of log entries discarded because the async buffer was full.", nil, nil),
+		encodeErrors: prometheus.NewDesc("toolhive_logger_encode_errors_total",
+			"Number of log entries which could not be encoded.", nil, nil),
+	}
+}
+
+type statsCollector struct {
+	emitted      *prometheus.Desc
+	sampledOut   *prometheus.Desc
+	dropped      *prometheus.Desc
+	encodeErrors *prometheus.Desc
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.emitted
+	ch <- c.sampledOut
+	ch <- c.dropped
+	ch <- c.encodeErrors
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := Stats()
+	ch <- prometheus.MustNewConstMetric(c.emitted, prometheus.CounterValue, float64(stats.Emitted))
+	ch <- prometheus.MustNewConstMetric(c.sampledOut, prometheus.CounterValue, float64(stats.SampledOut))
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(stats.Dropped))
+	ch <- prometheus.MustNewConstMetric(c.encodeErrors, prometheus.CounterValue, float64(stats.EncodeErrors))
+}
+
+// countSamplingDecision is the sampler hook counting entries discarded by sampling.
+func countSamplingDecision(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+	if decision&zapcore.LogDropped != 0 {
+		sampledOutEntries.Add(1)
+	}
+}
+
+// countingEncoder counts the entries its encoder fails to encode.
+type countingEncoder struct {
+	zapcore.Encoder
+}
+
+func (e *countingEncoder) Clone() zapcore.Encoder {
+	return &countingEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e *countingEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		encodeErrors.Add(1)
+	}
+	return buf, err
+}
+
+// countingWriteSyncer counts the entries successfully written to the log output.
+// Each call to Write carries exactly one encoded entry.
+type countingWriteSyncer struct {
+	zapcore.WriteSyncer
+}
+
+func (w *countingWriteSyncer) Write(p []byte) (int, error) {
+	n, err := w.WriteSyncer.Write(p)
+	if err == nil {
+		emittedEntries.Add(1)
+	}
+	return n, err
+}
@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// failingEncoder fails to encode every entry.
+type failingEncoder struct {
+	zapcore.Encoder
+}
+
+func (e failingEncoder) Clone() zapcore.Encoder {
+	return failingEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (failingEncoder) EncodeEntry(zapcore.Entry, []zapcore.Field) (*buffer.Buffer, error) {
+	return nil, errors.New("cannot encode")
+}
+
+// blockingWriter blocks every write until release is closed.
+type blockingWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func newStatsTestLogger(enc zapcore.Encoder, out zapcore.WriteSyncer) *zap.Logger {
+	return zap.New(zapcore.NewCore(&countingEncoder{Encoder: enc}, out, zapcore.DebugLevel))
+}
+
+func jsonEncoder() zapcore.Encoder {
+	return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+}
+
+//nolint:paralleltest // Stats counters are shared by every logger
+func TestStatsEmitted(t *testing.T) {
+	var out bytes.Buffer
+	log := newStatsTestLogger(jsonEncoder(), &countingWriteSyncer{WriteSyncer: zapcore.AddSync(&out)})
+	before := Stats()
+
+	log.Info("first")
+	log.With(zap.String("component", "test")).Info("second")
+
+	assert.Equal(t, before.Emitted+2, Stats().Emitted)
+	assert.Equal(t, before.EncodeErrors, Stats().EncodeErrors)
+}
+
+//nolint:paralleltest // Stats counters are shared by every logger
+func TestStatsEncodeErrors(t *testing.T) {
+	encoder := failingEncoder{Encoder: jsonEncoder()}
+	log := newStatsTestLogger(encoder, &countingWriteSyncer{WriteSyncer: zapcore.AddSync(io.Discard)})
+	before := Stats()
+
+	log.Info("unencodable")
+	log.With(zap.String("component", "test")).Info("unencodable")
+
+	assert.Equal(t, before.EncodeErrors+2, Stats().EncodeErrors)
+	assert.Equal(t, before.Emitted, Stats().Emitted)
+}
+
+//nolint:paralleltest // Stats counters are shared by every logger
+func TestStatsSampledOut(t *testing.T) {
+	core := zapcore.NewCore(jsonEncoder(), zapcore.AddSync(io.Discard), zapcore.DebugLevel)
+	core = zapcore.NewSamplerWithOptions(core, time.Minute, 1, 0, zapcore.SamplerHook(countSamplingDecision))
+	log := zap.New(core)
+	before := Stats()
+
+	for range 5 {
+		log.Info("repeated")
+	}
+
+	assert.Equal(t, before.SampledOut+4, Stats().SampledOut)
+}
+
+//nolint:paralleltest // Stats counters are shared by every logger
+func TestStatsDropped(t *testing.T) {
+	writer := &blockingWriter{release: make(chan struct{})}
+	async := newAsyncWriteSyncer(&countingWriteSyncer{WriteSyncer: zapcore.AddSync(writer)}, 1)
+	log := newStatsTestLogger(jsonEncoder(), async)
+	before := Stats()
+
+	// At most one entry is held by the blocked writer and one by the queue.
+	const entries = 10
+	for range entries {
+		log.Info("queued")
+	}
+	close(writer.release)
+	require.NoError(t, async.Sync())
+
+	after := Stats()
+	dropped := after.Dropped - before.Dropped
+	emitted := after.Emitted - before.Emitted
+	assert.GreaterOrEqual(t, dropped, uint64(entries-2))
+	assert.Equal(t, uint64(entries), dropped+emitted)
+	assert.Equal(t, int(emitted), bytes.Count(writer.buf.Bytes(), []byte("\n")))
+}
+
+func TestAsyncWriteSyncerPreservesOrder(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	async := newAsyncWriteSyncer(zapcore.AddSync(&out), 16)
+
+	for _, entry := range []string{"a\n", "b\n", "c\n"} {
+		_, err := async.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+	require.NoError(t, async.Sync())
+
+	assert.Equal(t, "a\nb\nc\n", out.String())
+}
+
+//nolint:paralleltest // Stats counters are shared by every logger
+func TestStatsCollector(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewStatsCollector()))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	stats := Stats()
+	values := map[string]float64{}
+	for _, family := range families {
+		require.Len(t, family.GetMetric(), 1)
+		values[family.GetName()] = family.GetMetric()[0].GetCounter().GetValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"toolhive_logger_entries_emitted_total":     float64(stats.Emitted),
+		"toolhive_logger_entries_sampled_out_total": float64(stats.SampledOut),
+		"toolhive_logger_entries_dropped_total":     float64(stats.Dropped),
+		"toolhive_logger_encode_errors_total":       float64(stats.EncodeErrors),
+	}, values)
+}
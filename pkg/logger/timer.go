@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StartTimer begins timing the operation op, and returns a function which logs the operation
+// with its elapsed time and the given extra key-value pairs once called. The entry is logged at
+// info level, or at warn level if the operation took at least warnAfter. A zero warnAfter
+// disables the warning.
+//
+//	defer l.StartTimer("pull image", 30*time.Second)("image", image)
+func (l *Logger) StartTimer(op string, warnAfter time.Duration) func(fields ...any) {
+	start := now()
+	return func(fields ...any) {
+		elapsed := since(start)
+		// Skip the returned function, so that entries report the caller which ended the timer.
+		logger := l.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+		fields = append([]any{zap.String("operation", op), zap.Duration("elapsed", elapsed)}, fields...)
+
+		if warnAfter > 0 && elapsed >= warnAfter {
+			logger.Warnw("slow operation", append(fields, zap.Duration("warn_after", warnAfter))...)
+			return
+		}
+		logger.Infow("operation completed", fields...)
+	}
+}
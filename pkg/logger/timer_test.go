@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStartTimer(t *testing.T) {
+	t.Parallel()
+
+	const sleep = 20 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		warnAfter time.Duration
+		level     zapcore.Level
+		message   string
+	}{
+		{"BelowThreshold", time.Minute, zapcore.InfoLevel, "operation completed"},
+		{"OverThreshold", 5 * time.Millisecond, zapcore.WarnLevel, "slow operation"},
+		{"NoThreshold", 0, zapcore.InfoLevel, "operation completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l, logs := newObservedLogger(zapcore.DebugLevel)
+
+			done := l.StartTimer("pull image", tt.warnAfter)
+			time.Sleep(sleep)
+			done("image", "ghcr.io/stackloklabs/fetch")
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.level, entries[0].Level)
+			assert.Equal(t, tt.message, entries[0].Message)
+
+			fields := entries[0].ContextMap()
+			assert.Equal(t, "pull image", fields["operation"])
+			assert.Equal(t, "ghcr.io/stackloklabs/fetch", fields["image"])
+			elapsed, ok := fields["elapsed"].(time.Duration)
+			require.True(t, ok)
+			assert.GreaterOrEqual(t, elapsed, sleep)
+			assert.Less(t, elapsed, sleep+time.Second)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceIDKey is the field holding the trace ID of the span in the context.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is the field holding the span ID of the span in the context.
+	SpanIDKey = "span_id"
+	// BaggageKeyPrefix is prepended to the keys of baggage members copied into log fields.
+	BaggageKeyPrefix = "baggage."
+)
+
+// TraceOption configures how WithTraceContext derives fields from a context.
+type TraceOption func(*traceOptions)
+
+type traceOptions struct {
+	baggageKeys []string
+}
+
+// WithBaggageKeys copies the named OpenTelemetry baggage members into log fields.
+// Members which are not listed are never logged, to avoid leaking high-cardinality data.
+func WithBaggageKeys(keys ...string) TraceOption {
+	return func(o *traceOptions) {
+		o.baggageKeys = append(o.baggageKeys, keys...)
+	}
+}
+
+// WithTraceContext returns a child Logger which tags every entry with the trace and
+// span IDs of the span in ctx, along with any allowlisted baggage members.
+// If ctx carries neither a valid span nor allowlisted baggage, l is returned unchanged.
+func (l *Logger) WithTraceContext(ctx context.Context, opts ...TraceOption) *Logger {
+	var options traceOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var keysAndValues []any
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		keysAndValues = append(keysAndValues,
+			TraceIDKey, spanContext.TraceID().String(),
+			SpanIDKey, spanContext.SpanID().String(),
+		)
+	}
+
+	bag := baggage.FromContext(ctx)
+	for _, key := range options.baggageKeys {
+		member := bag.Member(key)
+		if member.Key() == "" {
+			continue
+		}
+		keysAndValues = append(keysAndValues, BaggageKeyPrefix+key, member.Value())
+	}
+
+	if len(keysAndValues) == 0 {
+		return l
+	}
+	return l.With(keysAndValues...)
+}
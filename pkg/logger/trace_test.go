@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+func newBaggageContext(t *testing.T, members map[string]string) context.Context {
+	t.Helper()
+	var list []baggage.Member
+	for key, value := range members {
+		member, err := baggage.NewMember(key, value)
+		require.NoError(t, err)
+		list = append(list, member)
+	}
+	bag, err := baggage.New(list...)
+	require.NoError(t, err)
+	return baggage.ContextWithBaggage(context.Background(), bag)
+}
+
+func TestWithTraceContext(t *testing.T) {
+	t.Parallel()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	t.Run("SpanContext", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+
+		l.WithTraceContext(ctx).Info("traced")
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, map[string]any{
+			TraceIDKey: traceID.String(),
+			SpanIDKey:  spanID.String(),
+		}, entries[0].ContextMap())
+	})
+
+	t.Run("OnlyAllowlistedBaggage", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		ctx := newBaggageContext(t, map[string]string{
+			"tenant":  "acme",
+			"user.id": "12345",
+		})
+
+		l.WithTraceContext(ctx, WithBaggageKeys("tenant", "missing")).Info("with baggage")
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, map[string]any{BaggageKeyPrefix + "tenant": "acme"}, entries[0].ContextMap())
+	})
+
+	t.Run("NoBaggageWithoutAllowlist", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		ctx := newBaggageContext(t, map[string]string{"tenant": "acme"})
+
+		l.WithTraceContext(ctx).Info("without allowlist")
+
+		entries := logs.All()
+		require.Len(t, entries, 1)
+		assert.Empty(t, entries[0].ContextMap())
+	})
+}
@@ -0,0 +1,231 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// WebhookURLEnvVar is the environment variable holding the URL entries at warn level and above
+	// are posted to, e.g. the incoming webhook of a chat channel, as a JSON object holding their
+	// level, message, logger and fields. Entries with the same level and message are posted at most
+	// once per webhookInterval, so that a failure repeated in a loop does not flood the channel.
+	// When unset, entries are not posted.
+	WebhookURLEnvVar = "LOG_WEBHOOK_URL"
+
+	webhookInterval   = time.Minute
+	webhookMaxPending = 64
+	webhookTimeout    = 5 * time.Second
+)
+
+// webhookPayload is the JSON object posted to the webhook for an entry.
+type webhookPayload struct {
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Logger  string         `json:"logger,omitempty"`
+	Time    time.Time      `json:"time"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// webhookNotifier posts payloads to a webhook in the background, one at a time.
+// Payloads queued while too many are pending are dropped and counted in Stats.
+// The goroutine posting payloads only runs while some are pending, so that a notifier
+// does not outlive the logger it belongs to.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	pending []webhookPayload
+	// posting is closed once the running goroutine has posted every pending payload,
+	// or is nil if none is running.
+	posting chan struct{}
+	// lastPosted holds when entries were last posted, by level and message.
+	lastPosted map[webhookKey]time.Time
+	// pruned is when entries last posted over webhookInterval ago were last removed from lastPosted.
+	pruned time.Time
+}
+
+type webhookKey struct {
+	level   zapcore.Level
+	message string
+}
+
+// newWebhookNotifierFromEnv returns a notifier for the webhook configured in the environment,
+// or nil if none is configured.
+func newWebhookNotifierFromEnv() *webhookNotifier {
+	url := os.Getenv(WebhookURLEnvVar)
+	if url == "" {
+		return nil
+	}
+	return newWebhookNotifier(url)
+}
+
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{
+		url:        url,
+		client:     &http.Client{Timeout: webhookTimeout},
+		lastPosted: map[webhookKey]time.Time{},
+	}
+}
+
+// allow reports whether an entry with the given level and message may be posted at t, recording it if so.
+func (n *webhookNotifier) allow(level zapcore.Level, message string, t time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if t.Sub(n.pruned) >= webhookInterval {
+		for key, last := range n.lastPosted {
+			if t.Sub(last) >= webhookInterval {
+				delete(n.lastPosted, key)
+			}
+		}
+		n.pruned = t
+	}
+
+	key := webhookKey{level: level, message: message}
+	if last, ok := n.lastPosted[key]; ok && t.Sub(last) < webhookInterval {
+		return false
+	}
+	n.lastPosted[key] = t
+	return true
+}
+
+func (n *webhookNotifier) add(payload webhookPayload) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.pending) >= webhookMaxPending {
+		droppedEntries.Add(1)
+		return
+	}
+	n.pending = append(n.pending, payload)
+	if n.posting == nil {
+		n.posting = make(chan struct{})
+		go n.run(n.posting)
+	}
+}
+
+// flush waits for the payloads queued so far to be posted.
+func (n *webhookNotifier) flush() {
+	n.mu.Lock()
+	posting := n.posting
+	n.mu.Unlock()
+	if posting != nil {
+		<-posting
+	}
+}
+
+// run posts pending payloads until none are left, then closes done.
+func (n *webhookNotifier) run(done chan struct{}) {
+	defer close(done)
+	for {
+		n.mu.Lock()
+		if len(n.pending) == 0 {
+			n.posting = nil
+			n.mu.Unlock()
+			return
+		}
+		payload := n.pending[0]
+		n.pending = n.pending[1:]
+		n.mu.Unlock()
+
+		// Errors cannot be logged without feeding back into the notifier, so they are dropped.
+		_ = n.post(payload)
+	}
+}
+
+func (n *webhookNotifier) post(payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post to webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// webhookCore hands entries at warn level and above to a webhookNotifier.
+type webhookCore struct {
+	zapcore.LevelEnabler
+	fields   []zapcore.Field
+	notifier *webhookNotifier
+}
+
+func newWebhookCore(enabler zapcore.LevelEnabler, notifier *webhookNotifier) zapcore.Core {
+	return &webhookCore{LevelEnabler: enabler, notifier: notifier}
+}
+
+func (c *webhookCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.WarnLevel && c.LevelEnabler.Enabled(level)
+}
+
+func (c *webhookCore) With(fields []zapcore.Field) zapcore.Core {
+	return &webhookCore{
+		LevelEnabler: c.LevelEnabler,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+		notifier:     c.notifier,
+	}
+}
+
+func (c *webhookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *webhookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	// The cores wrapping the tee this core is part of may write entries to it without checking
+	// them against this core, so its level is checked again here.
+	if !c.Enabled(ent.Level) || !c.notifier.allow(ent.Level, ent.Message, ent.Time) {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range append(c.fields[:len(c.fields):len(c.fields)], fields...) {
+		field.AddTo(enc)
+	}
+	c.notifier.add(webhookPayload{
+		Level:   ent.Level.String(),
+		Message: ent.Message,
+		Logger:  ent.LoggerName,
+		Time:    ent.Time,
+		Fields:  enc.Fields,
+	})
+
+	// Entries above error level may be followed by the process exiting, so post them right away.
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+// Sync waits for the queued payloads to be posted.
+func (c *webhookCore) Sync() error {
+	c.notifier.flush()
+	return nil
+}
@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeWebhook records the payloads posted to it.
+type fakeWebhook struct {
+	*httptest.Server
+	mu       sync.Mutex
+	payloads []map[string]any
+}
+
+func newFakeWebhook(t *testing.T) *fakeWebhook {
+	t.Helper()
+	webhook := &fakeWebhook{}
+	webhook.Server = httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload map[string]any
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload)) {
+			return
+		}
+		webhook.mu.Lock()
+		defer webhook.mu.Unlock()
+		webhook.payloads = append(webhook.payloads, payload)
+	}))
+	t.Cleanup(webhook.Close)
+	return webhook
+}
+
+func (w *fakeWebhook) Payloads() []map[string]any {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]map[string]any{}, w.payloads...)
+}
+
+func TestWebhookCore(t *testing.T) {
+	t.Parallel()
+	webhook := newFakeWebhook(t)
+	clock := newFakeClock()
+	log := zap.New(newWebhookCore(zapcore.DebugLevel, newWebhookNotifier(webhook.URL)), zap.WithClock(clock)).
+		Named("runner").With(zap.String("server", "fetch"))
+
+	log.Info("server started")
+	log.Warn("server unhealthy", zap.Int("attempt", 1))
+	require.NoError(t, log.Sync())
+
+	payloads := webhook.Payloads()
+	require.Len(t, payloads, 1, "only entries at warn level and above are posted")
+	assert.Equal(t, "warn", payloads[0]["level"])
+	assert.Equal(t, "server unhealthy", payloads[0]["message"])
+	assert.Equal(t, "runner", payloads[0]["logger"])
+	assert.Equal(t, map[string]any{"server": "fetch", "attempt": float64(1)}, payloads[0]["fields"])
+}
+
+func TestWebhookCoreRateLimit(t *testing.T) {
+	t.Parallel()
+	webhook := newFakeWebhook(t)
+	clock := newFakeClock()
+	log := zap.New(newWebhookCore(zapcore.DebugLevel, newWebhookNotifier(webhook.URL)), zap.WithClock(clock))
+
+	for range 5 {
+		log.Error("failed to pull image")
+		clock.Advance(time.Second)
+	}
+	log.Warn("failed to pull image")
+	clock.Advance(webhookInterval)
+	log.Error("failed to pull image")
+	require.NoError(t, log.Sync())
+
+	var messages []string
+	for _, payload := range webhook.Payloads() {
+		messages = append(messages, payload["level"].(string)+": "+payload["message"].(string))
+	}
+	assert.Equal(t, []string{
+		"error: failed to pull image",
+		"warn: failed to pull image",
+		"error: failed to pull image",
+	}, messages)
+}
+
+func TestWebhookNotifierIdle(t *testing.T) {
+	t.Parallel()
+	webhook := newFakeWebhook(t)
+	notifier := newWebhookNotifier(webhook.URL)
+	log := zap.New(newWebhookCore(zapcore.DebugLevel, notifier))
+
+	notifier.flush()
+	log.Warn("server unhealthy")
+	require.NoError(t, log.Sync())
+	assert.Len(t, webhook.Payloads(), 1)
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	assert.Nil(t, notifier.posting, "no goroutine runs once every payload is posted")
+	assert.Empty(t, notifier.pending)
+}
+
+func TestWebhookNotifierPrunesLastPosted(t *testing.T) {
+	t.Parallel()
+	notifier := newWebhookNotifier("http://localhost")
+	start := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, notifier.allow(zapcore.ErrorLevel, "failed to pull image fetch", start))
+	assert.True(t, notifier.allow(zapcore.ErrorLevel, "failed to pull image time", start.Add(time.Second)))
+	assert.False(t, notifier.allow(zapcore.ErrorLevel, "failed to pull image time", start.Add(webhookInterval)))
+	assert.Equal(t, map[webhookKey]time.Time{
+		{level: zapcore.ErrorLevel, message: "failed to pull image time"}: start.Add(time.Second),
+	}, notifier.lastPosted, "entries last posted over webhookInterval ago are removed")
+
+	assert.True(t, notifier.allow(zapcore.ErrorLevel, "failed to pull image git", start.Add(2*webhookInterval)))
+	assert.Equal(t, map[webhookKey]time.Time{
+		{level: zapcore.ErrorLevel, message: "failed to pull image git"}: start.Add(2 * webhookInterval),
+	}, notifier.lastPosted)
+}
+
+func TestWebhookFromEnv(t *testing.T) { //nolint:paralleltest // Uses environment variables
+	t.Setenv("UNSTRUCTURED_LOGS", "false")
+
+	t.Setenv(WebhookURLEnvVar, "")
+	assert.Nil(t, newWebhookNotifierFromEnv())
+
+	webhook := newFakeWebhook(t)
+	t.Setenv(WebhookURLEnvVar, webhook.URL)
+	l := NewLogger()
+	l.Info("disk usage checked")
+	l.Warnw("disk almost full", "free", "1%")
+	// Syncing stdout may fail depending on what it is attached to, but the webhook is flushed regardless.
+	_ = l.Sync()
+
+	payloads := webhook.Payloads()
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "disk almost full", payloads[0]["message"])
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelWriter returns an io.Writer which emits every line written to it as an entry at the
+// given level. This allows libraries which log through an io.Writer, or through a standard
+// library *log.Logger, to produce structured entries. Partial writes are buffered until the
+// line is completed by a newline, and empty lines are discarded.
+func LevelWriter(l *Logger, level zapcore.Level) io.Writer {
+	return &levelWriter{
+		logger: l.Desugar().WithOptions(zap.AddCallerSkip(1)),
+		level:  level,
+	}
+}
+
+type levelWriter struct {
+	mu     sync.Mutex
+	logger *zap.Logger
+	level  zapcore.Level
+	buf    []byte
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimRight(w.buf[:i], "\r")
+		if len(line) > 0 {
+			w.logger.Log(w.level, string(line))
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PartialWrites", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		w := LevelWriter(l, zapcore.WarnLevel)
+
+		_, err := w.Write([]byte("first "))
+		require.NoError(t, err)
+		_, err = w.Write([]byte("line"))
+		require.NoError(t, err)
+		assert.Zero(t, logs.Len(), "partial lines must be buffered")
+
+		_, err = w.Write([]byte("\nsecond line\r\n\nthird"))
+		require.NoError(t, err)
+
+		entries := logs.All()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "first line", entries[0].Message)
+		assert.Equal(t, "second line", entries[1].Message)
+		for _, entry := range entries {
+			assert.Equal(t, zapcore.WarnLevel, entry.Level)
+		}
+
+		_, err = w.Write([]byte(" line\n"))
+		require.NoError(t, err)
+		require.Equal(t, 3, logs.Len())
+		assert.Equal(t, "third line", logs.All()[2].Message)
+	})
+
+	t.Run("StandardLibraryLogger", func(t *testing.T) {
+		t.Parallel()
+		l, logs := newObservedLogger(zapcore.DebugLevel)
+		stdLogger := log.New(LevelWriter(l, zapcore.DebugLevel), "", 0)
+
+		for i := range 3 {
+			stdLogger.Printf("library message %d", i)
+		}
+
+		entries := logs.All()
+		require.Len(t, entries, 3)
+		for i, entry := range entries {
+			assert.Equal(t, fmt.Sprintf("library message %d", i), entry.Message)
+			assert.Equal(t, zapcore.DebugLevel, entry.Level)
+		}
+	})
+}
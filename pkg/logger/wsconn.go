@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Keys of the fields added to the entries logged for streaming connections.
+const (
+	// ConnIDKey is the field holding the ID of a WebSocket or SSE connection.
+	ConnIDKey = "conn_id"
+	// RemoteAddrKey is the field holding the remote address of a connection.
+	RemoteAddrKey = "remote_addr"
+	// CloseCodeKey is the field holding the WebSocket close code of a connection.
+	CloseCodeKey = "close_code"
+)
+
+// LogWSConnect logs at info level that the WebSocket or SSE connection with the given ID was
+// established from remoteAddr. The same ID is given to LogWSClose and LogWSError, so that the
+// entries of a connection can be correlated.
+func LogWSConnect(l *Logger, connID, remoteAddr string) {
+	wsLogger(l).Infow("stream connected", zap.String(ConnIDKey, connID), zap.String(RemoteAddrKey, remoteAddr))
+}
+
+// LogWSClose logs at info level that the connection with the given ID was closed after being
+// open for d, with the given WebSocket close code, e.g. 1000 for a normal closure. A code of 0,
+// as for SSE streams which have none, is left out.
+func LogWSClose(l *Logger, connID, remoteAddr string, d time.Duration, code int) {
+	fields := []any{zap.String(ConnIDKey, connID), zap.String(RemoteAddrKey, remoteAddr), zap.Duration("duration", d)}
+	if code != 0 {
+		fields = append(fields, zap.Int(CloseCodeKey, code))
+	}
+	wsLogger(l).Infow("stream closed", fields...)
+}
+
+// LogWSError logs at error level that the connection with the given ID failed with err.
+func LogWSError(l *Logger, connID, remoteAddr string, err error) {
+	wsLogger(l).Errorw("stream error",
+		zap.String(ConnIDKey, connID), zap.String(RemoteAddrKey, remoteAddr), zap.Error(err))
+}
+
+// wsLogger returns l skipping the helper logging the entry, so that entries report its caller.
+func wsLogger(l *Logger) *zap.SugaredLogger {
+	return l.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogWSLifecycle(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	LogWSConnect(l, "conn-1", "10.0.0.1:51234")
+	LogWSError(l, "conn-1", "10.0.0.1:51234", errors.New("unexpected EOF"))
+	LogWSClose(l, "conn-1", "10.0.0.1:51234", 90*time.Second, 1006)
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.Equal(t, "conn-1", entry.ContextMap()[ConnIDKey], entry.Message)
+		assert.Equal(t, "10.0.0.1:51234", entry.ContextMap()[RemoteAddrKey], entry.Message)
+	}
+
+	assert.Equal(t, "stream connected", entries[0].Message)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.NotContains(t, entries[0].ContextMap(), "duration")
+
+	assert.Equal(t, "stream error", entries[1].Message)
+	assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	assert.Equal(t, "unexpected EOF", entries[1].ContextMap()["error"])
+
+	assert.Equal(t, "stream closed", entries[2].Message)
+	assert.Equal(t, zapcore.InfoLevel, entries[2].Level)
+	assert.Equal(t, 90*time.Second, entries[2].ContextMap()["duration"])
+	assert.Equal(t, int64(1006), entries[2].ContextMap()[CloseCodeKey])
+}
+
+func TestLogWSCloseWithoutCode(t *testing.T) {
+	t.Parallel()
+	l, logs := newObservedLogger(zapcore.DebugLevel)
+
+	LogWSClose(l, "sse-7", "10.0.0.2:40000", time.Minute, 0)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, time.Minute, entries[0].ContextMap()["duration"])
+	assert.NotContains(t, entries[0].ContextMap(), CloseCodeKey)
+}
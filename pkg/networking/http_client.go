@@ -103,6 +103,14 @@ func NewHttpClientBuilder() *HttpClientBuilder {
 	}
 }
 
+// WithTimeout sets the timeout for requests made by the client. A zero timeout keeps the default.
+func (b *HttpClientBuilder) WithTimeout(timeout time.Duration) *HttpClientBuilder {
+	if timeout > 0 {
+		b.clientTimeout = timeout
+	}
+	return b
+}
+
 // WithCABundle sets the CA certificate bundle path
 func (b *HttpClientBuilder) WithCABundle(path string) *HttpClientBuilder {
 	b.caCertPath = path
@@ -174,7 +182,7 @@ func (b *HttpClientBuilder) Build() (*http.Client, error) {
 
 	client := &http.Client{
 		Transport: clientTransport,
-		Timeout:   HttpTimeout,
+		Timeout:   b.clientTimeout,
 	}
 
 	return client, nil
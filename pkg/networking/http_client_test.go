@@ -29,6 +29,19 @@ func TestNewHttpClientBuilder(t *testing.T) {
 	assert.False(t, builder.allowPrivate)
 }
 
+func TestHttpClientBuilder_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	builder := NewHttpClientBuilder()
+
+	result := builder.WithTimeout(5 * time.Second)
+	assert.Same(t, builder, result) // fluent interface
+	assert.Equal(t, 5*time.Second, builder.clientTimeout)
+
+	builder.WithTimeout(0)
+	assert.Equal(t, 5*time.Second, builder.clientTimeout, "a zero timeout keeps the previous one")
+}
+
 func TestHttpClientBuilder_WithCABundle(t *testing.T) {
 	t.Parallel()
 
@@ -109,6 +122,20 @@ func TestHttpClientBuilder_Build(t *testing.T) {
 				assert.IsType(t, &ValidatingTransport{}, client.Transport)
 			},
 		},
+		{
+			name: "client with timeout",
+			setupBuilder: func() *HttpClientBuilder {
+				return NewHttpClientBuilder().WithTimeout(5 * time.Second)
+			},
+			setupFiles: func(_ *testing.T) (string, string) {
+				return "", ""
+			},
+			expectError: false,
+			validateClient: func(t *testing.T, client *http.Client) {
+				t.Helper()
+				assert.Equal(t, 5*time.Second, client.Timeout)
+			},
+		},
 		{
 			name: "client with valid CA bundle",
 			setupBuilder: func() *HttpClientBuilder {